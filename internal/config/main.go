@@ -15,33 +15,55 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v2"
 )
 
 type FortiExporterParameter struct {
-	AuthFile      *string
-	Listen        *string
-	ScrapeTimeout *int
-	TLSTimeout    *int
-	TLSInsecure   *bool
-	TLSExtraCAs   *string
-	MaxBGPPaths   *int
-	MaxVPNUsers   *int
+	AuthFile       *string
+	Listen         *string
+	ScrapeTimeout  *int
+	TLSTimeout     *int
+	TLSInsecure    *bool
+	TLSExtraCAs    *string
+	MaxBGPPaths    *int
+	MaxVPNUsers    *int
+	MaxListEntries *int
+	MaxSessions    *int
+	TopSessions    *int
+	MaxSecCats     *int
+	RogueAPInfo    *bool
+	MaxRespSize    *string
+	MaxRespSizes   *string
 }
 
 type FortiExporterConfig struct {
-	AuthKeys      AuthKeys
-	Listen        string
-	ScrapeTimeout int
-	TLSTimeout    int
-	TLSInsecure   bool
-	TLSExtraCAs   []LocalCert
-	MaxBGPPaths   int
-	MaxVPNUsers   int
+	AuthKeys       AuthKeys
+	Listen         string
+	ScrapeTimeout  int
+	TLSTimeout     int
+	TLSInsecure    bool
+	TLSExtraCAs    []LocalCert
+	MaxBGPPaths    int
+	MaxVPNUsers    int
+	MaxListEntries int
+	// MaxSessions limits the sessions fetched for the top-N breakdown, 0 disables it
+	MaxSessions int
+	TopSessions int
+	// MaxSecurityCategories limits the categories reported per VDOM by the
+	// Security probes, 0 means unlimited
+	MaxSecurityCategories int
+	// RogueAPInfo enables the info series per detected foreign access point
+	RogueAPInfo bool
+	// MaxResponseSize limits the size of API responses in bytes, 0 means unlimited
+	MaxResponseSize int64
+	// MaxResponseSizes overrides MaxResponseSize per API path
+	MaxResponseSizes map[string]int64
 }
 
 type AuthKeys map[Target]TargetAuth
@@ -57,9 +79,32 @@ type Probes struct {
 	Exclude ProbeList
 }
 
+// HAMembers lists the probes which are run once per HA cluster member
+// instead of only against the unit answering on the target address.
+type HAMembers struct {
+	Probes ProbeList
+}
+
+// RouteWatch lists prefixes whose presence in the routing table is reported
+// by the Router/Routes probe.
+type RouteWatch struct {
+	Prefixes []string
+}
+
+// FirmwarePolicy sets the firmware version the System/Firmware probe
+// compares the running version against.
+type FirmwarePolicy struct {
+	MinimumVersion string `yaml:"minimum_version"`
+}
+
 type TargetAuth struct {
-	Token  Token
-	Probes Probes
+	Token    Token
+	Probes   Probes
+	HA       HAMembers
+	CMDB     []CMDBProbe
+	Custom   []CustomProbe
+	Routes   RouteWatch
+	Firmware FirmwarePolicy
 }
 
 type LocalCert struct {
@@ -69,14 +114,21 @@ type LocalCert struct {
 
 var (
 	parameter = FortiExporterParameter{
-		AuthFile:      flag.String("auth-file", "fortigate-key.yaml", "file containing the authentication map to use when connecting to a Fortigate device"),
-		Listen:        flag.String("listen", ":9710", "address to listen on"),
-		ScrapeTimeout: flag.Int("scrape-timeout", 30, "max seconds to allow a scrape to take"),
-		TLSTimeout:    flag.Int("https-timeout", 10, "TLS Handshake timeout in seconds"),
-		TLSInsecure:   flag.Bool("insecure", false, "Allow insecure certificates"),
-		TLSExtraCAs:   flag.String("extra-ca-certs", "", "comma-separated files containing extra PEMs to trust for TLS connections in addition to the system trust store"),
-		MaxBGPPaths:   flag.Int("max-bgp-paths", 10000, "How many BGP Paths to receive when counting routes, needs to be greater than or equal to the number of routes or metrics will not be generated"),
-		MaxVPNUsers:   flag.Int("max-vpn-users", 0, "How many VPN Users to receive when counting users, needs to be greater than or equal the number of users or metrics will not be generated (0 eq. none by default)"),
+		AuthFile:       flag.String("auth-file", "fortigate-key.yaml", "file containing the authentication map to use when connecting to a Fortigate device"),
+		Listen:         flag.String("listen", ":9710", "address to listen on"),
+		ScrapeTimeout:  flag.Int("scrape-timeout", 30, "max seconds to allow a scrape to take"),
+		TLSTimeout:     flag.Int("https-timeout", 10, "TLS Handshake timeout in seconds"),
+		TLSInsecure:    flag.Bool("insecure", false, "Allow insecure certificates"),
+		TLSExtraCAs:    flag.String("extra-ca-certs", "", "comma-separated files containing extra PEMs to trust for TLS connections in addition to the system trust store"),
+		MaxBGPPaths:    flag.Int("max-bgp-paths", 10000, "How many BGP Paths to receive at most per IP stack version when counting routes, further paths are skipped and reported by fortigate_probe_truncated (0 eq. no BGP path metrics)"),
+		MaxVPNUsers:    flag.Int("max-vpn-users", 0, "How many VPN Users to receive when counting users, needs to be greater than or equal the number of users or metrics will not be generated (0 eq. none by default)"),
+		MaxListEntries: flag.Int("max-list-entries", 10000, "How many entries to receive at most from paginated list endpoints like wifi clients, larger lists are truncated (0 eq. no limit)"),
+		MaxSessions:    flag.Int("max-sessions", 0, "How many sessions to receive at most for the top source, destination and application breakdown of the Firewall/Sessions probe (0 eq. no breakdown by default)"),
+		TopSessions:    flag.Int("top-sessions", 10, "How many top sources, destinations and applications to report per VDOM in the Firewall/Sessions probe"),
+		MaxSecCats:     flag.Int("max-security-categories", 20, "How many web filter and application control categories to report per VDOM, blocked events of further categories are reported by a separate overflow counter (0 eq. no limit)"),
+		RogueAPInfo:    flag.Bool("rogue-ap-info", false, "Report an info series per detected foreign access point in the Wifi/RogueAP/Info probe"),
+		MaxRespSize:    flag.String("max-response-size", "64MiB", "maximum size of an API response, larger responses fail the probe (0 eq. no limit)"),
+		MaxRespSizes:   flag.String("max-response-size-endpoints", "", "comma-separated API path=size pairs overriding -max-response-size for single endpoints"),
 	}
 
 	savedConfig *FortiExporterConfig
@@ -100,12 +152,42 @@ func ReInit() error {
 	flag.Parse()
 
 	savedConfig = &FortiExporterConfig{
-		Listen:        *parameter.Listen,
-		ScrapeTimeout: *parameter.ScrapeTimeout,
-		TLSTimeout:    *parameter.TLSTimeout,
-		TLSInsecure:   *parameter.TLSInsecure,
-		MaxBGPPaths:   *parameter.MaxBGPPaths,
-		MaxVPNUsers:   *parameter.MaxVPNUsers,
+		Listen:                *parameter.Listen,
+		ScrapeTimeout:         *parameter.ScrapeTimeout,
+		TLSTimeout:            *parameter.TLSTimeout,
+		TLSInsecure:           *parameter.TLSInsecure,
+		MaxBGPPaths:           *parameter.MaxBGPPaths,
+		MaxVPNUsers:           *parameter.MaxVPNUsers,
+		MaxListEntries:        *parameter.MaxListEntries,
+		MaxSessions:           *parameter.MaxSessions,
+		TopSessions:           *parameter.TopSessions,
+		MaxSecurityCategories: *parameter.MaxSecCats,
+		RogueAPInfo:           *parameter.RogueAPInfo,
+	}
+
+	// parse response size limits
+	size, err := parseSize(*parameter.MaxRespSize)
+	if err != nil {
+		log.Fatalf("Invalid -max-response-size: %v", err)
+		return err
+	}
+	savedConfig.MaxResponseSize = size
+	savedConfig.MaxResponseSizes = map[string]int64{}
+	for ep := range strings.SplitSeq(*parameter.MaxRespSizes, ",") {
+		if ep == "" {
+			continue
+		}
+		path, s, ok := strings.Cut(ep, "=")
+		if !ok {
+			log.Fatalf("Invalid -max-response-size-endpoints entry %q, expected path=size", ep)
+			return fmt.Errorf("invalid entry %q", ep)
+		}
+		size, err := parseSize(s)
+		if err != nil {
+			log.Fatalf("Invalid -max-response-size-endpoints entry %q: %v", ep, err)
+			return err
+		}
+		savedConfig.MaxResponseSizes[strings.TrimPrefix(path, "/")] = size
 	}
 
 	// parse AuthKeys
@@ -120,6 +202,33 @@ func ReInit() error {
 		return err
 	}
 
+	for target, auth := range savedConfig.AuthKeys {
+		for _, cp := range auth.CMDB {
+			if err := cp.validate(); err != nil {
+				log.Fatalf("Invalid CMDB probe for %q: %v", target, err)
+				return err
+			}
+		}
+		for _, cp := range auth.Custom {
+			if err := cp.validate(); err != nil {
+				log.Fatalf("Invalid custom probe for %q: %v", target, err)
+				return err
+			}
+		}
+		if err := validateProbeNames(auth.CMDB, auth.Custom); err != nil {
+			log.Fatalf("Invalid probes for %q: %v", target, err)
+			return err
+		}
+		if err := auth.Routes.validate(); err != nil {
+			log.Fatalf("Invalid watched routes for %q: %v", target, err)
+			return err
+		}
+		if err := auth.Firmware.validate(); err != nil {
+			log.Fatalf("Invalid firmware policy for %q: %v", target, err)
+			return err
+		}
+	}
+
 	log.Printf("Loaded %d API keys", len(savedConfig.AuthKeys))
 
 	// parse ExtraCAs
@@ -144,6 +253,23 @@ func ReInit() error {
 	return nil
 }
 
+// parseSize parses a size in bytes with an optional KiB, MiB or GiB suffix.
+func parseSize(s string) (int64, error) {
+	mult := int64(1)
+	for suffix, m := range map[string]int64{"KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30} {
+		if strings.HasSuffix(s, suffix) {
+			s = strings.TrimSuffix(s, suffix)
+			mult = m
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * mult, nil
+}
+
 func GetConfig() FortiExporterConfig {
 	return *savedConfig
 }
@@ -0,0 +1,265 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/version"
+)
+
+// CMDBProbe describes a metric generated from the rows of a CMDB table,
+// e.g. api/v2/cmdb/firewall/address.
+type CMDBProbe struct {
+	// Name of the generated metric, used as prefix when Values are set
+	Name string
+	Help string
+	// Path of the table below api/v2/cmdb/
+	Path string
+	// Rows is the dot-separated path to the rows in the response of each VDOM
+	Rows string
+	// Labels maps label names to the row fields providing their value
+	Labels map[string]string
+	// Values maps metric name suffixes to the row fields providing their value
+	Values map[string]string
+}
+
+// CustomProbe describes metrics generated from a monitor API endpoint,
+// e.g. api/v2/monitor/system/traffic-history/interface.
+type CustomProbe struct {
+	// Name of the probe, which is run as Custom/<Name>
+	Name string
+	// Path of the endpoint below api/v2/monitor/
+	Path  string
+	Query string
+	// VDOM queries all VDOMs and adds a "vdom" label to every metric
+	VDOM bool
+	// Results is the dot-separated path to the rows in the response
+	Results string
+	// KeyLabel treats Results as object of rows and puts their keys into this label
+	KeyLabel string `yaml:"key_label"`
+	// Labels maps label names to the row fields providing their value
+	Labels  map[string]string
+	Metrics []CustomMetric
+}
+
+// CustomMetric describes a single metric of a CustomProbe.
+type CustomMetric struct {
+	Name string
+	Help string
+	// Field providing the value, the number of rows is used if empty
+	Field string
+	// Scale is multiplied with the value, e.g. 0.01 for percentages
+	Scale float64
+	// Type is either "gauge" (default) or "counter"
+	Type string
+}
+
+var (
+	metricNameRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRE  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	probeNameRE  = regexp.MustCompile(`^[a-zA-Z0-9_\-]+(/[a-zA-Z0-9_\-]+)*$`)
+	apiPathRE    = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+(/[a-zA-Z0-9_\-.]+)*$`)
+)
+
+func validateAPIPath(path string) error {
+	if strings.HasPrefix(path, "api/") || strings.HasPrefix(path, "/api/") {
+		return fmt.Errorf("path %q must be relative to the API root, e.g. %q", path, "system/status")
+	}
+	if !apiPathRE.MatchString(path) {
+		return fmt.Errorf("invalid path %q", path)
+	}
+	return nil
+}
+
+func validateJSONPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	for p := range strings.SplitSeq(path, ".") {
+		if p == "" {
+			return fmt.Errorf("invalid JSON path %q, empty element", path)
+		}
+	}
+	return nil
+}
+
+func validateLabels(labels map[string]string, metric string, reserved ...string) error {
+	for l, f := range labels {
+		if !labelNameRE.MatchString(l) || strings.HasPrefix(l, "__") {
+			return fmt.Errorf("invalid label name %q for %q", l, metric)
+		}
+		for _, r := range reserved {
+			if l == r {
+				return fmt.Errorf("label name %q for %q is reserved", l, metric)
+			}
+		}
+		if f == "" {
+			return fmt.Errorf("no field set for label %q of %q", l, metric)
+		}
+		if err := validateJSONPath(f); err != nil {
+			return fmt.Errorf("label %q of %q: %v", l, metric, err)
+		}
+	}
+	return nil
+}
+
+func (cp CMDBProbe) validate() error {
+	if !metricNameRE.MatchString(cp.Name) {
+		return fmt.Errorf("invalid metric name %q", cp.Name)
+	}
+	if err := validateAPIPath(cp.Path); err != nil {
+		return fmt.Errorf("metric %q: %v", cp.Name, err)
+	}
+	if err := validateJSONPath(cp.Rows); err != nil {
+		return fmt.Errorf("rows of metric %q: %v", cp.Name, err)
+	}
+	if err := validateLabels(cp.Labels, cp.Name, "vdom"); err != nil {
+		return err
+	}
+	for v, f := range cp.Values {
+		if !metricNameRE.MatchString(cp.Name + "_" + v) {
+			return fmt.Errorf("invalid value name %q for metric %q", v, cp.Name)
+		}
+		if f == "" {
+			return fmt.Errorf("no field set for value %q of metric %q", v, cp.Name)
+		}
+		if err := validateJSONPath(f); err != nil {
+			return fmt.Errorf("value %q of metric %q: %v", v, cp.Name, err)
+		}
+	}
+	return nil
+}
+
+func (cp CustomProbe) validate() error {
+	if !probeNameRE.MatchString(cp.Name) {
+		return fmt.Errorf("invalid probe name %q", cp.Name)
+	}
+	if err := validateAPIPath(cp.Path); err != nil {
+		return fmt.Errorf("probe %q: %v", cp.Name, err)
+	}
+	q, err := url.ParseQuery(cp.Query)
+	if err != nil {
+		return fmt.Errorf("invalid query of probe %q: %v", cp.Name, err)
+	}
+	if _, ok := q["vdom"]; ok && cp.VDOM {
+		return fmt.Errorf("probe %q sets vdom in query, which conflicts with per-VDOM handling", cp.Name)
+	}
+	if err := validateJSONPath(cp.Results); err != nil {
+		return fmt.Errorf("results of probe %q: %v", cp.Name, err)
+	}
+
+	reserved := []string{}
+	if cp.VDOM {
+		reserved = append(reserved, "vdom")
+	}
+	if cp.KeyLabel != "" {
+		if !labelNameRE.MatchString(cp.KeyLabel) {
+			return fmt.Errorf("invalid key label %q for probe %q", cp.KeyLabel, cp.Name)
+		}
+		reserved = append(reserved, cp.KeyLabel)
+	}
+	if err := validateLabels(cp.Labels, cp.Name, reserved...); err != nil {
+		return err
+	}
+
+	if len(cp.Metrics) == 0 {
+		return fmt.Errorf("no metrics defined for probe %q", cp.Name)
+	}
+	names := map[string]bool{}
+	for _, cm := range cp.Metrics {
+		if !metricNameRE.MatchString(cm.Name) {
+			return fmt.Errorf("invalid metric name %q in probe %q", cm.Name, cp.Name)
+		}
+		if names[cm.Name] {
+			return fmt.Errorf("duplicate metric name %q in probe %q", cm.Name, cp.Name)
+		}
+		names[cm.Name] = true
+		if err := validateJSONPath(cm.Field); err != nil {
+			return fmt.Errorf("metric %q: %v", cm.Name, err)
+		}
+		if cm.Type != "" && cm.Type != "gauge" && cm.Type != "counter" {
+			return fmt.Errorf("invalid type %q of metric %q, expected gauge or counter", cm.Type, cm.Name)
+		}
+	}
+	return nil
+}
+
+// metricNames returns the names of the metrics generated by the probe.
+func (cp CMDBProbe) metricNames() []string {
+	if len(cp.Values) == 0 {
+		return []string{cp.Name}
+	}
+	names := []string{}
+	for v := range cp.Values {
+		names = append(names, cp.Name+"_"+v)
+	}
+	return names
+}
+
+// validateProbeNames checks that the CMDB and custom probes of a target have
+// unique probe names and do not generate metrics of the same name, which
+// would otherwise only fail when the metrics are gathered.
+func validateProbeNames(cmdb []CMDBProbe, custom []CustomProbe) error {
+	probes := map[string]bool{}
+	metrics := map[string]string{}
+	for _, cp := range cmdb {
+		if probes["CMDB/"+cp.Name] {
+			return fmt.Errorf("duplicate CMDB probe %q", cp.Name)
+		}
+		probes["CMDB/"+cp.Name] = true
+		for _, name := range cp.metricNames() {
+			if other, ok := metrics[name]; ok {
+				return fmt.Errorf("metric %q of CMDB probe %q is already generated by %s", name, cp.Name, other)
+			}
+			metrics[name] = fmt.Sprintf("CMDB probe %q", cp.Name)
+		}
+	}
+	for _, cp := range custom {
+		if probes["Custom/"+cp.Name] {
+			return fmt.Errorf("duplicate custom probe %q", cp.Name)
+		}
+		probes["Custom/"+cp.Name] = true
+		for _, cm := range cp.Metrics {
+			if other, ok := metrics[cm.Name]; ok {
+				return fmt.Errorf("metric %q of custom probe %q is already generated by %s", cm.Name, cp.Name, other)
+			}
+			metrics[cm.Name] = fmt.Sprintf("custom probe %q", cp.Name)
+		}
+	}
+	return nil
+}
+
+func (rw RouteWatch) validate() error {
+	for _, p := range rw.Prefixes {
+		if _, err := netip.ParsePrefix(p); err != nil {
+			return fmt.Errorf("invalid prefix %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+func (fp FirmwarePolicy) validate() error {
+	if fp.MinimumVersion == "" {
+		return nil
+	}
+	if _, ok := version.ParseFullVersion(fp.MinimumVersion); !ok {
+		return fmt.Errorf("invalid minimum version %q, expected e.g. %q", fp.MinimumVersion, "7.2.8")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestCustomProbeValidate(t *testing.T) {
+	valid := CustomProbe{
+		Name:     "TrafficHistory",
+		Path:     "system/traffic-history/interface",
+		Query:    "interface=wan1&time_period=hour",
+		Results:  "results",
+		KeyLabel: "interface",
+		Labels:   map[string]string{"name": "name"},
+		Metrics: []CustomMetric{
+			{Name: "fortigate_traffic_history_rx_bps", Field: "rx", Scale: 8, Type: "gauge"},
+		},
+	}
+	if err := valid.validate(); err != nil {
+		t.Errorf("validate() returned error for valid probe: %v", err)
+	}
+
+	for name, modify := range map[string]func(*CustomProbe){
+		"probe name":     func(cp *CustomProbe) { cp.Name = "Traffic History" },
+		"absolute path":  func(cp *CustomProbe) { cp.Path = "api/v2/monitor/system/traffic-history" },
+		"empty segment":  func(cp *CustomProbe) { cp.Path = "system//traffic-history" },
+		"path query":     func(cp *CustomProbe) { cp.Path = "system/traffic-history?interface=wan1" },
+		"vdom in query":  func(cp *CustomProbe) { cp.VDOM = true; cp.Query = "vdom=root" },
+		"results path":   func(cp *CustomProbe) { cp.Results = "results..rx" },
+		"field path":     func(cp *CustomProbe) { cp.Metrics[0].Field = "rx." },
+		"label name":     func(cp *CustomProbe) { cp.Labels = map[string]string{"if-name": "name"} },
+		"key label":      func(cp *CustomProbe) { cp.Labels = map[string]string{"interface": "name"} },
+		"metric name":    func(cp *CustomProbe) { cp.Metrics[0].Name = "traffic-rx" },
+		"metric type":    func(cp *CustomProbe) { cp.Metrics[0].Type = "histogram" },
+		"no metrics":     func(cp *CustomProbe) { cp.Metrics = nil },
+		"duplicate name": func(cp *CustomProbe) { cp.Metrics = append(cp.Metrics, cp.Metrics[0]) },
+	} {
+		cp := valid
+		cp.Metrics = append([]CustomMetric{}, valid.Metrics...)
+		modify(&cp)
+		if err := cp.validate(); err == nil {
+			t.Errorf("validate() returned no error for invalid %s", name)
+		}
+	}
+}
+
+func TestCMDBProbeValidate(t *testing.T) {
+	valid := CMDBProbe{
+		Name:   "fortigate_firewall_address_objects",
+		Path:   "firewall/address",
+		Labels: map[string]string{"type": "type"},
+	}
+	if err := valid.validate(); err != nil {
+		t.Errorf("validate() returned error for valid probe: %v", err)
+	}
+
+	for name, cp := range map[string]CMDBProbe{
+		"metric name": {Name: "firewall-address", Path: "firewall/address"},
+		"no path":     {Name: "fortigate_firewall_address_objects"},
+		"vdom label":  {Name: "fortigate_firewall_address_objects", Path: "firewall/address", Labels: map[string]string{"vdom": "name"}},
+		"value field": {Name: "fortigate_firewall_address", Path: "firewall/address", Values: map[string]string{"ttl": ""}},
+	} {
+		if err := cp.validate(); err == nil {
+			t.Errorf("validate() returned no error for invalid %s", name)
+		}
+	}
+}
+
+func TestValidateProbeNames(t *testing.T) {
+	cmdb := []CMDBProbe{
+		{Name: "fortigate_firewall_address_objects", Path: "firewall/address"},
+		{Name: "fortigate_firewall_address", Path: "firewall/address", Values: map[string]string{"ttl": "cache-ttl"}},
+	}
+	custom := []CustomProbe{
+		{Name: "LinkMonitor", Metrics: []CustomMetric{{Name: "fortigate_custom_link_latency_seconds"}}},
+	}
+	if err := validateProbeNames(cmdb, custom); err != nil {
+		t.Errorf("validateProbeNames() returned error for unique names: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		cmdb   []CMDBProbe
+		custom []CustomProbe
+	}{
+		"cmdb probe":      {cmdb: append(cmdb, CMDBProbe{Name: "fortigate_firewall_address", Path: "firewall/address6"})},
+		"cmdb value":      {cmdb: append(cmdb, CMDBProbe{Name: "fortigate_firewall_address_ttl", Path: "firewall/address6"})},
+		"custom probe":    {custom: append(custom, CustomProbe{Name: "LinkMonitor", Metrics: []CustomMetric{{Name: "fortigate_custom_link_jitter_seconds"}}})},
+		"cmdb and custom": {cmdb: cmdb, custom: append(custom, CustomProbe{Name: "Address", Metrics: []CustomMetric{{Name: "fortigate_firewall_address_objects"}}})},
+	} {
+		if err := validateProbeNames(tc.cmdb, tc.custom); err == nil {
+			t.Errorf("validateProbeNames() returned no error for duplicate %s", name)
+		}
+	}
+}
+
+func TestRouteWatchValidate(t *testing.T) {
+	if err := (RouteWatch{Prefixes: []string{"0.0.0.0/0", "10.0.0.0/8", "2001:db8::/32"}}).validate(); err != nil {
+		t.Errorf("validate() returned error for valid prefixes: %v", err)
+	}
+	for _, p := range []string{"10.0.0.0", "10.0.0.0/33", "example.com/24"} {
+		if err := (RouteWatch{Prefixes: []string{p}}).validate(); err == nil {
+			t.Errorf("validate() returned no error for invalid prefix %q", p)
+		}
+	}
+}
+
+func TestFirmwarePolicyValidate(t *testing.T) {
+	for _, v := range []string{"", "7.2.8", "v7.4.3"} {
+		if err := (FirmwarePolicy{MinimumVersion: v}).validate(); err != nil {
+			t.Errorf("validate() returned error for valid version %q: %v", v, err)
+		}
+	}
+	for _, v := range []string{"7.2", "latest", "7.2.8-beta"} {
+		if err := (FirmwarePolicy{MinimumVersion: v}).validate(); err == nil {
+			t.Errorf("validate() returned no error for invalid version %q", v)
+		}
+	}
+}
@@ -32,6 +32,7 @@ package version
 
 import (
 	"fmt"
+	"strings"
 )
 
 func ParseVersion(ver string) (int, int, bool) {
@@ -46,3 +47,15 @@ func ParseVersion(ver string) (int, int, bool) {
 	}
 	return major, minor, true
 }
+
+// ParseFullVersion parses a version including the patch level, e.g. "v7.2.5",
+// the leading "v" is optional.
+func ParseFullVersion(ver string) ([3]int, bool) {
+	var v [3]int
+	var rest string
+	n, _ := fmt.Sscanf(strings.TrimPrefix(ver, "v")+" ", "%d.%d.%d%s", &v[0], &v[1], &v[2], &rest)
+	if n != 3 {
+		return v, false
+	}
+	return v, true
+}
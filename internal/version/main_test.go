@@ -58,3 +58,26 @@ func TestVersionParseOK(t *testing.T) {
 		})
 	}
 }
+
+func TestFullVersionParse(t *testing.T) {
+	for _, tv := range []struct {
+		v   string
+		ver [3]int
+		ok  bool
+	}{
+		{v: "v7.2.5", ver: [3]int{7, 2, 5}, ok: true},
+		{v: "7.4.10", ver: [3]int{7, 4, 10}, ok: true},
+		{v: "v7.2", ok: false},
+		{v: "7.2.x", ok: false},
+	} {
+		t.Run(tv.v, func(t *testing.T) {
+			ver, ok := ParseFullVersion(tv.v)
+			if ok != tv.ok {
+				t.Fatalf("Expected ok of %q to be %v, was %v", tv.v, tv.ok, ok)
+			}
+			if ok && ver != tv.ver {
+				t.Errorf("Expected %q to be %v, was %v", tv.v, tv.ver, ver)
+			}
+		})
+	}
+}
@@ -46,10 +46,47 @@ type Client interface {
 }
 
 type fortiTokenClient struct {
-	tgt url.URL
-	hc  Client
-	ctx context.Context
-	tok config.Token
+	tgt    url.URL
+	hc     Client
+	ctx    context.Context
+	tok    config.Token
+	limits ResponseLimits
+}
+
+// ResponseLimits caps the size of API responses in bytes, 0 means unlimited.
+type ResponseLimits struct {
+	Default int64
+	// Endpoints overrides the default per API path, e.g. api/v2/monitor/router/bgp/paths
+	Endpoints map[string]int64
+}
+
+// limitedReader fails reads once more than limit bytes have been read. It
+// reads up to one byte beyond the limit to tell a body of exactly limit bytes
+// from a larger one.
+type limitedReader struct {
+	r     io.Reader
+	read  int64
+	limit int64
+	path  string
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, l.exceeded()
+	}
+	if left := l.limit - l.read + 1; int64(len(p)) > left {
+		p = p[:left]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n - int(l.read-l.limit), l.exceeded()
+	}
+	return n, err
+}
+
+func (l *limitedReader) exceeded() error {
+	return fmt.Errorf("response exceeds maximum size of %d bytes (path: %q)", l.limit, l.path)
 }
 
 func (c *fortiTokenClient) newGetRequest(url string) (*http.Request, error) {
@@ -62,6 +99,14 @@ func (c *fortiTokenClient) newGetRequest(url string) (*http.Request, error) {
 }
 
 func (c *fortiTokenClient) Get(path, query string, obj any) error {
+	return c.Stream(path, query, func(d *json.Decoder) error {
+		return d.Decode(obj)
+	})
+}
+
+// Stream requests path and passes a decoder reading the response body while
+// it is received to fn, which avoids buffering large responses in memory.
+func (c *fortiTokenClient) Stream(path, query string, fn func(*json.Decoder) error) error {
 	u := c.tgt
 	u.Path = path
 	u.RawQuery = query
@@ -76,15 +121,23 @@ func (c *fortiTokenClient) Get(path, query string, obj any) error {
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("response code was %d, expected 200 (path: %q)", resp.StatusCode, path)
+		return &StatusError{Code: resp.StatusCode, Path: path}
 	}
 
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+	var body io.Reader = resp.Body
+	if limit := c.maxResponseSize(path); limit > 0 {
+		body = &limitedReader{r: resp.Body, limit: limit, path: path}
+	}
+	return fn(json.NewDecoder(body))
+}
+
+func (c *fortiTokenClient) maxResponseSize(path string) int64 {
+	if limit, ok := c.limits.Endpoints[path]; ok {
+		return limit
 	}
-	return json.Unmarshal(b, obj)
+	return c.limits.Default
 }
 
 func (c *fortiTokenClient) String() string {
@@ -92,5 +145,5 @@ func (c *fortiTokenClient) String() string {
 }
 
 func newFortiTokenClient(ctx context.Context, tgt url.URL, hc Client, token config.Token) (*fortiTokenClient, error) {
-	return &fortiTokenClient{tgt, hc, ctx, token}, nil
+	return &fortiTokenClient{tgt: tgt, hc: hc, ctx: ctx, tok: token}, nil
 }
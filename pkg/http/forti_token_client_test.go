@@ -82,4 +82,96 @@ func TestGetFail(t *testing.T) {
 	if err == nil {
 		t.Errorf("Get() expected non-nil error, got nil error")
 	}
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) returned false, expected true", err)
+	}
+}
+
+func TestGetTooLarge(t *testing.T) {
+	c, _ := newClient(200, `{ "data": "test" }`)
+	c.limits = ResponseLimits{Default: 8}
+	type D struct {
+		Data string
+	}
+	if err := c.Get("test", "", &D{}); err == nil {
+		t.Errorf("Get() expected non-nil error, got nil error")
+	}
+
+	c.limits.Endpoints = map[string]int64{"test": 64}
+	var v D
+	if err := c.Get("test", "", &v); err != nil || v.Data != "test" {
+		t.Errorf("Get() %v, %v, expected %v, nil", v, err, D{"test"})
+	}
+}
+
+func TestGetSizeBoundary(t *testing.T) {
+	body := `{ "data": "test" }`
+	c, _ := newClient(200, body)
+	type D struct {
+		Data string
+	}
+	c.limits = ResponseLimits{Default: int64(len(body))}
+	var v D
+	if err := c.Get("test", "", &v); err != nil || v.Data != "test" {
+		t.Errorf("Get() %v, %v with a body of exactly the limit, expected %v, nil", v, err, D{"test"})
+	}
+
+	c.limits = ResponseLimits{Default: int64(len(body)) - 1}
+	if err := c.Get("test", "", &D{}); err == nil {
+		t.Errorf("Get() expected non-nil error for a body one byte over the limit, got nil error")
+	}
+
+	for _, tc := range []struct {
+		limit int64
+		fail  bool
+	}{
+		{limit: int64(len(body)), fail: false},
+		{limit: int64(len(body)) + 1, fail: false},
+		{limit: int64(len(body)) - 1, fail: true},
+	} {
+		b, err := io.ReadAll(&limitedReader{r: strings.NewReader(body), limit: tc.limit, path: "test"})
+		if (err != nil) != tc.fail {
+			t.Errorf("limitedReader with limit %d returned %v, expected failure %v", tc.limit, err, tc.fail)
+		}
+		if !tc.fail && string(b) != body {
+			t.Errorf("limitedReader with limit %d read %q, expected %q", tc.limit, b, body)
+		}
+		if tc.fail && int64(len(b)) != tc.limit {
+			t.Errorf("limitedReader with limit %d passed on %d bytes, expected %d", tc.limit, len(b), tc.limit)
+		}
+	}
+}
+
+func TestGetAllStream(t *testing.T) {
+	c, _ := newClient(200, `[
+		{ "results": [ 1, 2 ], "vdom": "root", "status": "success" },
+		{ "http_method": "GET", "vdom": "guest", "results": [ 3 ] }
+	]`)
+	res, truncated, err := GetAll[int](c, "test", "vdom=*", 0)
+	exp := []ListResponse[int]{
+		{Results: []int{1, 2}, VDOM: "root"},
+		{Results: []int{3}, VDOM: "guest"},
+	}
+	if err != nil || truncated || !reflect.DeepEqual(res, exp) {
+		t.Errorf("GetAll() %v, %v, %v, expected %v, false, nil", res, truncated, err, exp)
+	}
+}
+
+func TestGetAllStreamIgnoredPaging(t *testing.T) {
+	// Every request returns the same full page of entries, with or without
+	// echoing the start before them
+	entries := strings.Repeat("7, ", ListPageSize-1) + "7"
+	for _, body := range []string{
+		`[ { "start": 0, "results": [ ` + entries + ` ], "vdom": "root" } ]`,
+		`[ { "results": [ ` + entries + ` ], "vdom": "root" } ]`,
+	} {
+		c, _ := newClient(200, body)
+		res, truncated, err := GetAll[int](c, "test", "vdom=*", 0)
+		if err != nil || !truncated {
+			t.Fatalf("GetAll() returned %v, %v, expected true, nil", truncated, err)
+		}
+		if len(res) != 1 || len(res[0].Results) != ListPageSize {
+			t.Errorf("GetAll() returned %d VDOMs, expected 1 with %d entries", len(res), ListPageSize)
+		}
+	}
 }
@@ -0,0 +1,60 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+)
+
+// HAMemberParameter is the query parameter FortiOS uses to forward an API
+// request from the cluster primary to the HA member with the given serial.
+const HAMemberParameter = "ha_serial"
+
+type haMemberClient struct {
+	c      FortiHTTP
+	serial string
+}
+
+func (c *haMemberClient) query(query string) string {
+	if query != "" {
+		query += "&"
+	}
+	return query + HAMemberParameter + "=" + url.QueryEscape(c.serial)
+}
+
+func (c *haMemberClient) Get(path, query string, obj any) error {
+	return c.c.Get(path, c.query(query), obj)
+}
+
+// Stream streams the response if the wrapped client supports it, otherwise
+// the buffered response is passed to fn.
+func (c *haMemberClient) Stream(path, query string, fn func(*json.Decoder) error) error {
+	if s, ok := c.c.(Streamer); ok {
+		return s.Stream(path, c.query(query), fn)
+	}
+	var raw json.RawMessage
+	if err := c.c.Get(path, c.query(query), &raw); err != nil {
+		return err
+	}
+	return fn(json.NewDecoder(bytes.NewReader(raw)))
+}
+
+// NewHAMemberClient returns a client which sends every request to the HA
+// cluster member identified by serial instead of the unit answering on the
+// target address.
+func NewHAMemberClient(c FortiHTTP, serial string) FortiHTTP {
+	return &haMemberClient{c, serial}
+}
@@ -0,0 +1,82 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recordingClient struct {
+	path  string
+	query string
+}
+
+func (c *recordingClient) Get(path, query string, _ any) error {
+	c.path = path
+	c.query = query
+	return nil
+}
+
+func TestHAMemberClient(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		exp   string
+	}{
+		{"", "ha_serial=FGT61E4QXXXXXXXX2"},
+		{"vdom=*", "vdom=*&ha_serial=FGT61E4QXXXXXXXX2"},
+	} {
+		rc := &recordingClient{}
+		c := NewHAMemberClient(rc, "FGT61E4QXXXXXXXX2")
+		if err := c.Get("api/v2/monitor/system/interface", tc.query, nil); err != nil {
+			t.Fatalf("Get() returned error: %v", err)
+		}
+		if rc.path != "api/v2/monitor/system/interface" || rc.query != tc.exp {
+			t.Errorf("Get(%q) sent %q, %q, expected %q", tc.query, rc.path, rc.query, tc.exp)
+		}
+	}
+}
+
+// streamingClient serves body to Stream and fails Get
+type streamingClient struct {
+	recordingClient
+	body string
+}
+
+func (c *streamingClient) Get(_, _ string, _ any) error {
+	return errors.New("unexpected buffered request")
+}
+
+func (c *streamingClient) Stream(path, query string, fn func(*json.Decoder) error) error {
+	c.path = path
+	c.query = query
+	return fn(json.NewDecoder(strings.NewReader(c.body)))
+}
+
+func TestHAMemberClientStream(t *testing.T) {
+	sc := &streamingClient{body: `[ { "results": [ 1, 2 ], "vdom": "root" } ]`}
+	c := NewHAMemberClient(sc, "FGT61E4QXXXXXXXX2")
+	res, _, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 0)
+	if err != nil {
+		t.Fatalf("GetAll() returned error: %v", err)
+	}
+	if len(res) != 1 || len(res[0].Results) != 2 {
+		t.Errorf("GetAll() returned %v, expected 2 entries of VDOM root", res)
+	}
+	if exp := "vdom=*&start=0&count=1000&ha_serial=FGT61E4QXXXXXXXX2"; sc.query != exp {
+		t.Errorf("GetAll() streamed %q, expected %q", sc.query, exp)
+	}
+}
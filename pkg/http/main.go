@@ -17,6 +17,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -30,6 +31,23 @@ type FortiHTTP interface {
 	Get(path, query string, obj any) error
 }
 
+// StatusError is returned for responses with a status code other than 200.
+type StatusError struct {
+	Code int
+	Path string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("response code was %d, expected 200 (path: %q)", e.Code, e.Path)
+}
+
+// IsNotFound reports whether err is caused by a 404 response, which FortiOS
+// returns for endpoints the device does not support.
+func IsNotFound(err error) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.Code == http.StatusNotFound
+}
+
 func NewFortiClient(ctx context.Context, tgt url.URL, hc *http.Client, aConfig config.FortiExporterConfig) (FortiHTTP, error) {
 	auth, ok := aConfig.AuthKeys[config.Target(tgt.String())]
 	if !ok {
@@ -44,6 +62,10 @@ func NewFortiClient(ctx context.Context, tgt url.URL, hc *http.Client, aConfig c
 		if err != nil {
 			return nil, err
 		}
+		c.limits = ResponseLimits{
+			Default:   aConfig.MaxResponseSize,
+			Endpoints: aConfig.MaxResponseSizes,
+		}
 		return c, nil
 	}
 	return nil, fmt.Errorf("invalid authentication data for %q", tgt.String())
@@ -0,0 +1,307 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// ListPageSize is the number of entries requested per page by GetAll.
+const ListPageSize = 1000
+
+// ListResponse is the response of a list endpoint for a single VDOM.
+type ListResponse[T any] struct {
+	Results []T    `json:"results"`
+	VDOM    string `json:"vdom"`
+	// Total is only reported by some endpoints
+	Total *int `json:"total,omitempty"`
+}
+
+// ListVisitor receives the entries of a list endpoint one VDOM after another.
+// As the VDOM name may follow the results in a response, EndVDOM is called
+// after all entries of a VDOM in a page have been passed to Entry.
+type ListVisitor[T any] interface {
+	Entry(entry T)
+	EndVDOM(vdom string)
+}
+
+// Streamer is implemented by clients which can decode a response while it
+// is received instead of buffering it first.
+type Streamer interface {
+	Stream(path, query string, fn func(*json.Decoder) error) error
+}
+
+type pageStats struct {
+	vdom    string
+	entries int
+	total   *int
+	// start is the start of the page as echoed by the endpoint, if it does
+	start *int
+	// ignored is set if the echoed start differs from the requested one
+	ignored bool
+}
+
+// listPage is a page of a list endpoint for a single VDOM with the entries
+// not decoded yet.
+type listPage struct {
+	Results []json.RawMessage `json:"results"`
+	VDOM    string            `json:"vdom"`
+	Total   *int              `json:"total,omitempty"`
+	Start   *int              `json:"start,omitempty"`
+}
+
+// pageVisitor passes the entries of a page on to a ListVisitor, unless the
+// endpoint echoed a different start than requested before the entries.
+type pageVisitor[T any] struct {
+	v     ListVisitor[T]
+	start int
+}
+
+func (p *pageVisitor[T]) setStart(start int, st *pageStats) {
+	st.start = &start
+	st.ignored = start != p.start
+}
+
+func (p *pageVisitor[T]) entry(raw json.RawMessage, st *pageStats) error {
+	if st.ignored {
+		return nil
+	}
+	var e T
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return err
+	}
+	p.v.Entry(e)
+	st.entries++
+	return nil
+}
+
+func (p *pageVisitor[T]) endVDOM(st *pageStats) {
+	if !st.ignored || st.entries > 0 {
+		p.v.EndVDOM(st.vdom)
+	}
+}
+
+type listCollector[T any] struct {
+	res     []ListResponse[T]
+	vdoms   map[string]int
+	entries []T
+}
+
+func (l *listCollector[T]) Entry(entry T) {
+	l.entries = append(l.entries, entry)
+}
+
+func (l *listCollector[T]) EndVDOM(vdom string) {
+	i, ok := l.vdoms[vdom]
+	if !ok {
+		i = len(l.res)
+		l.vdoms[vdom] = i
+		l.res = append(l.res, ListResponse[T]{VDOM: vdom})
+	}
+	l.res[i].Results = append(l.res[i].Results, l.entries...)
+	l.entries = nil
+}
+
+// GetAll fetches all entries of a list endpoint supporting start/count
+// pagination, merging the results of every page per VDOM. See VisitAll.
+func GetAll[T any](c FortiHTTP, path, query string, limit int) (res []ListResponse[T], truncated bool, err error) {
+	l := &listCollector[T]{vdoms: map[string]int{}}
+	truncated, err = VisitAll[T](c, path, query, limit, l)
+	if err != nil {
+		return nil, false, err
+	}
+	return l.res, truncated, nil
+}
+
+// VisitAll walks all entries of a list endpoint supporting start/count
+// pagination. Query must select the VDOMs to return, e.g. "vdom=*". Pages
+// are requested until every VDOM reported less entries than requested or
+// its reported total is reached. If limit is greater than zero no more pages
+// are requested once limit entries have been received, in which case
+// truncated is set if there were more entries to fetch.
+//
+// Whether an endpoint honours start is told by the response metadata: a
+// further page is only requested for a full page echoing the requested start
+// or reporting a total. A VDOM returning more entries than requested has
+// ignored count and returned everything. A full page without any of this
+// metadata is the only one requested and reported as truncated, as is a page
+// echoing a different start than requested, whose entries are skipped if the
+// start precedes them in the response.
+//
+// Clients implementing Streamer have the entries decoded one by one while the
+// response is received, so only a single entry needs to be kept in memory.
+func VisitAll[T any](c FortiHTTP, path, query string, limit int, v ListVisitor[T]) (truncated bool, err error) {
+	fetched := map[string]int{}
+	total := 0
+	pv := &pageVisitor[T]{v: v}
+	for start := 0; ; {
+		count := ListPageSize
+		if limit > 0 && limit-total < count {
+			count = limit - total
+		}
+
+		q := fmt.Sprintf("start=%d&count=%d", start, count)
+		if query != "" {
+			q = query + "&" + q
+		}
+		pv.start = start
+		page, err := visitPage(c, path, q, pv)
+		if err != nil {
+			return false, err
+		}
+
+		more, ignored, unknown := false, false, false
+		for _, p := range page {
+			fetched[p.vdom] += p.entries
+			total += p.entries
+			switch {
+			case p.ignored:
+				ignored = true
+			// Less entries are the last page, more mean count was ignored
+			// and everything has been returned
+			case p.entries != count:
+			case p.total != nil:
+				more = more || fetched[p.vdom] < *p.total
+			case p.start != nil:
+				more = true
+			default:
+				unknown = true
+			}
+		}
+
+		if ignored {
+			log.Printf("Warning: %s ignores the start parameter, only its first page is used", path)
+			return true, nil
+		}
+		if unknown {
+			log.Printf("Warning: %s reports neither start nor total, only its first page is used", path)
+			return true, nil
+		}
+		if !more {
+			return false, nil
+		}
+		if limit > 0 && total >= limit {
+			return true, nil
+		}
+		start += count
+	}
+}
+
+func visitPage[T any](c FortiHTTP, path, query string, pv *pageVisitor[T]) ([]pageStats, error) {
+	if s, ok := c.(Streamer); ok {
+		var stats []pageStats
+		err := s.Stream(path, query, func(d *json.Decoder) error {
+			var err error
+			stats, err = decodeListPage(d, pv)
+			return err
+		})
+		return stats, err
+	}
+
+	var page []listPage
+	if err := c.Get(path, query, &page); err != nil {
+		return nil, err
+	}
+	stats := []pageStats{}
+	for _, p := range page {
+		st := pageStats{vdom: p.VDOM, total: p.Total}
+		if p.Start != nil {
+			pv.setStart(*p.Start, &st)
+		}
+		for _, e := range p.Results {
+			if err := pv.entry(e, &st); err != nil {
+				return nil, err
+			}
+		}
+		pv.endVDOM(&st)
+		stats = append(stats, st)
+	}
+	return stats, nil
+}
+
+// decodeListPage decodes a list of per-VDOM responses token by token,
+// passing every entry of the results to pv as soon as it has been decoded.
+func decodeListPage[T any](d *json.Decoder, pv *pageVisitor[T]) ([]pageStats, error) {
+	if err := expectDelim(d, '['); err != nil {
+		return nil, err
+	}
+	stats := []pageStats{}
+	for d.More() {
+		if err := expectDelim(d, '{'); err != nil {
+			return nil, err
+		}
+		st := pageStats{}
+		for d.More() {
+			t, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			switch t {
+			case "results":
+				if err := expectDelim(d, '['); err != nil {
+					return nil, err
+				}
+				for d.More() {
+					var e json.RawMessage
+					if err := d.Decode(&e); err != nil {
+						return nil, err
+					}
+					if err := pv.entry(e, &st); err != nil {
+						return nil, err
+					}
+				}
+				if err := expectDelim(d, ']'); err != nil {
+					return nil, err
+				}
+			case "vdom":
+				err = d.Decode(&st.vdom)
+			case "total":
+				err = d.Decode(&st.total)
+			case "start":
+				var start int
+				if err = d.Decode(&start); err == nil {
+					pv.setStart(start, &st)
+				}
+			default:
+				var skip json.RawMessage
+				err = d.Decode(&skip)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := expectDelim(d, '}'); err != nil {
+			return nil, err
+		}
+		pv.endVDOM(&st)
+		stats = append(stats, st)
+	}
+	if err := expectDelim(d, ']'); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func expectDelim(d *json.Decoder, delim json.Delim) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("unexpected JSON token %v, expected %v", t, delim)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// pagedClient serves a list endpoint with the given number of entries per VDOM
+type pagedClient struct {
+	entries map[string]int
+	queries []string
+	// ignorePaging returns all entries regardless of start/count
+	ignorePaging bool
+	// meta selects the paging metadata of the responses: "start" echoes the
+	// start of the page, "total" reports the number of entries, "" none
+	meta string
+}
+
+func (c *pagedClient) Get(_, query string, obj any) error {
+	c.queries = append(c.queries, query)
+	q, err := url.ParseQuery(query)
+	if err != nil {
+		return err
+	}
+	start, _ := strconv.Atoi(q.Get("start"))
+	count, _ := strconv.Atoi(q.Get("count"))
+	if c.ignorePaging {
+		start, count = 0, c.entries["root"]+c.entries["guest"]
+	}
+	type page struct {
+		Results []int  `json:"results"`
+		VDOM    string `json:"vdom"`
+		Start   *int   `json:"start,omitempty"`
+		Total   *int   `json:"total,omitempty"`
+	}
+	pages := []page{}
+	for _, vdom := range []string{"root", "guest"} {
+		p := page{Results: []int{}, VDOM: vdom}
+		for i := start; i < start+count && i < c.entries[vdom]; i++ {
+			p.Results = append(p.Results, i)
+		}
+		switch c.meta {
+		case "start":
+			p.Start = &start
+		case "total":
+			total := c.entries[vdom]
+			p.Total = &total
+		}
+		pages = append(pages, p)
+	}
+	b, _ := json.Marshal(pages)
+	return json.Unmarshal(b, obj)
+}
+
+func TestGetAll(t *testing.T) {
+	c := &pagedClient{entries: map[string]int{"root": 2500, "guest": 10}, meta: "start"}
+	res, truncated, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 0)
+	if err != nil || truncated {
+		t.Fatalf("GetAll() returned %v, %v, expected false, nil", truncated, err)
+	}
+	if len(res) != 2 || len(res[0].Results) != 2500 || len(res[1].Results) != 10 {
+		t.Errorf("GetAll() returned unexpected results: %d VDOMs", len(res))
+	}
+	for i, r := range res[0].Results {
+		if r != i {
+			t.Fatalf("GetAll() returned entry %d at position %d", r, i)
+		}
+	}
+	exp := []string{
+		"vdom=*&start=0&count=1000",
+		"vdom=*&start=1000&count=1000",
+		"vdom=*&start=2000&count=1000",
+	}
+	if len(c.queries) != len(exp) {
+		t.Fatalf("GetAll() sent queries %q, expected %q", c.queries, exp)
+	}
+	for i := range exp {
+		if c.queries[i] != exp[i] {
+			t.Errorf("GetAll() sent query %q, expected %q", c.queries[i], exp[i])
+		}
+	}
+}
+
+func TestGetAllLimit(t *testing.T) {
+	c := &pagedClient{entries: map[string]int{"root": 2500}, meta: "start"}
+	res, truncated, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 1500)
+	if err != nil || !truncated {
+		t.Fatalf("GetAll() returned %v, %v, expected true, nil", truncated, err)
+	}
+	if len(res[0].Results) != 1500 {
+		t.Errorf("GetAll() returned %d entries, expected 1500", len(res[0].Results))
+	}
+	if c.queries[1] != "vdom=*&start=1000&count=500" {
+		t.Errorf("GetAll() sent query %q, expected %q", c.queries[1], "vdom=*&start=1000&count=500")
+	}
+
+	c = &pagedClient{entries: map[string]int{"root": 1500}, meta: "start"}
+	if _, truncated, _ := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 2000); truncated {
+		t.Errorf("GetAll() reported truncation below the limit")
+	}
+}
+
+func TestGetAllTotal(t *testing.T) {
+	// The last page is full, the total tells there are no more entries
+	c := &pagedClient{entries: map[string]int{"root": 2000}, meta: "total"}
+	res, truncated, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 0)
+	if err != nil || truncated {
+		t.Fatalf("GetAll() returned %v, %v, expected false, nil", truncated, err)
+	}
+	if len(res[0].Results) != 2000 {
+		t.Errorf("GetAll() returned %d entries, expected 2000", len(res[0].Results))
+	}
+	if len(c.queries) != 2 {
+		t.Errorf("GetAll() sent queries %q, expected 2", c.queries)
+	}
+}
+
+func TestGetAllIgnoredPaging(t *testing.T) {
+	for _, tc := range []struct {
+		entries   int
+		limit     int
+		meta      string
+		queries   int
+		truncated bool
+	}{
+		// More entries than requested, so count is ignored
+		{entries: 2500, limit: 0, queries: 1},
+		{entries: 2500, limit: 2000, queries: 1},
+		{entries: 2500, limit: 0, meta: "start", queries: 1},
+		// Exactly the requested entries, the second page echoes start 0
+		{entries: 1000, limit: 0, meta: "start", queries: 2, truncated: true},
+		{entries: 1000, limit: 5000, meta: "start", queries: 2, truncated: true},
+		// Exactly the requested entries without metadata, paging is not tried
+		{entries: 1000, limit: 0, queries: 1, truncated: true},
+	} {
+		c := &pagedClient{entries: map[string]int{"root": tc.entries}, ignorePaging: true, meta: tc.meta}
+		res, truncated, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", tc.limit)
+		if err != nil || truncated != tc.truncated {
+			t.Fatalf("GetAll(%d) returned %v, %v, expected %v, nil", tc.entries, truncated, err, tc.truncated)
+		}
+		if len(res[0].Results) != tc.entries {
+			t.Errorf("GetAll(%d) returned %d entries, expected %d", tc.entries, len(res[0].Results), tc.entries)
+		}
+		if len(c.queries) != tc.queries {
+			t.Errorf("GetAll(%d) sent queries %q, expected %d", tc.entries, c.queries, tc.queries)
+		}
+	}
+}
+
+func TestGetAllWithoutPagingMetadata(t *testing.T) {
+	// Paging works, but nothing in the response tells so
+	c := &pagedClient{entries: map[string]int{"root": 2500}}
+	res, truncated, err := GetAll[int](c, "api/v2/monitor/wifi/client", "vdom=*", 0)
+	if err != nil || !truncated {
+		t.Fatalf("GetAll() returned %v, %v, expected true, nil", truncated, err)
+	}
+	if len(res[0].Results) != ListPageSize || len(c.queries) != 1 {
+		t.Errorf("GetAll() returned %d entries in %d queries, expected %d in 1", len(res[0].Results), len(c.queries), ListPageSize)
+	}
+}
@@ -14,7 +14,6 @@
 package probe
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,17 +27,48 @@ type BGPPath struct {
 	IsBest      bool   `json:"is_best"`
 }
 
-type BGPPaths struct {
-	Results []BGPPath `json:"results"`
-	VDOM    string    `json:"vdom"`
-	Version string    `json:"version"`
-}
-
 type PathCount struct {
 	Source string
 	VDOM   string
 }
 
+// bgpPathCounter counts the paths per neighbor while they are decoded, so
+// the potentially huge path lists never have to be kept in memory.
+type bgpPathCounter struct {
+	srMap  map[PathCount]int
+	sr2Map map[PathCount]int
+	// counts of the VDOM currently decoded, keyed by neighbor
+	paths     map[string]int
+	bestPaths map[string]int
+}
+
+func newBGPPathCounter() *bgpPathCounter {
+	return &bgpPathCounter{
+		srMap:     make(map[PathCount]int),
+		sr2Map:    make(map[PathCount]int),
+		paths:     make(map[string]int),
+		bestPaths: make(map[string]int),
+	}
+}
+
+func (b *bgpPathCounter) Entry(route BGPPath) {
+	b.paths[route.LearnedFrom]++
+	if route.IsBest {
+		b.bestPaths[route.LearnedFrom]++
+	}
+}
+
+func (b *bgpPathCounter) EndVDOM(vdom string) {
+	for neighbor, count := range b.paths {
+		b.srMap[PathCount{Source: neighbor, VDOM: vdom}] += count
+	}
+	for neighbor, count := range b.bestPaths {
+		b.sr2Map[PathCount{Source: neighbor, VDOM: vdom}] += count
+	}
+	clear(b.paths)
+	clear(b.bestPaths)
+}
+
 func probeBGPNeighborPathsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
 	savedConfig := config.GetConfig()
 	MaxBGPPaths := savedConfig.MaxBGPPaths
@@ -64,43 +94,21 @@ func probeBGPNeighborPathsIPv4(c http.FortiHTTP, meta *TargetMetadata) ([]promet
 		)
 	)
 
-	var rs []BGPPaths
-
-	if err := c.Get("api/v2/monitor/router/bgp/paths", fmt.Sprintf("vdom=*&count=%d", MaxBGPPaths), &rs); err != nil {
+	counter := newBGPPathCounter()
+	truncated, err := http.VisitAll[BGPPath](c, "api/v2/monitor/router/bgp/paths", "vdom=*", MaxBGPPaths, counter)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
+	if truncated {
+		log.Printf("Warning: Received more BGP Paths than maximum (%d) allowed, path counts are incomplete", MaxBGPPaths)
+	}
 
-	m := []prometheus.Metric{}
-
-	srMap := make(map[PathCount]int)
-	sr2Map := make(map[PathCount]int)
-	for _, r := range rs {
-
-		if len(r.Results) > MaxBGPPaths {
-			log.Printf("Error: Received more BGP Paths than maximum (%d > %d) allowed, ignoring metric ...", len(r.Results), MaxBGPPaths)
-			return nil, false
-		}
-		for _, route := range r.Results {
-			sr := PathCount{
-				Source: route.LearnedFrom,
-				VDOM:   r.VDOM,
-			}
-			srMap[sr]++
-			if route.IsBest {
-				sr2 := PathCount{
-					Source: route.LearnedFrom,
-					VDOM:   r.VDOM,
-				}
-				sr2Map[sr2]++
-			}
-		}
-	}
-
-	for neighbor, count := range srMap {
+	m := []prometheus.Metric{probeTruncated("BGP/NeighborPaths/IPv4", truncated)}
+	for neighbor, count := range counter.srMap {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
-	for neighbor, count := range sr2Map {
+	for neighbor, count := range counter.sr2Map {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborBestPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
 
@@ -132,43 +140,21 @@ func probeBGPNeighborPathsIPv6(c http.FortiHTTP, meta *TargetMetadata) ([]promet
 		)
 	)
 
-	var rs []BGPPaths
-
-	if err := c.Get("api/v2/monitor/router/bgp/paths6", fmt.Sprintf("vdom=*&count=%d", MaxBGPPaths), &rs); err != nil {
+	counter := newBGPPathCounter()
+	truncated, err := http.VisitAll[BGPPath](c, "api/v2/monitor/router/bgp/paths6", "vdom=*", MaxBGPPaths, counter)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
+	if truncated {
+		log.Printf("Warning: Received more BGP Paths than maximum (%d) allowed, path counts are incomplete", MaxBGPPaths)
+	}
 
-	m := []prometheus.Metric{}
-
-	srMap := make(map[PathCount]int)
-	sr2Map := make(map[PathCount]int)
-	for _, r := range rs {
-
-		if len(r.Results) > MaxBGPPaths {
-			log.Printf("Error: Received more BGP Paths than maximum (%d > %d) allowed, ignoring metric ...", len(r.Results), MaxBGPPaths)
-			return nil, false
-		}
-		for _, route := range r.Results {
-			sr := PathCount{
-				Source: route.LearnedFrom,
-				VDOM:   r.VDOM,
-			}
-			srMap[sr]++
-			if route.IsBest {
-				sr2 := PathCount{
-					Source: route.LearnedFrom,
-					VDOM:   r.VDOM,
-				}
-				sr2Map[sr2]++
-			}
-		}
-	}
-
-	for neighbor, count := range srMap {
+	m := []prometheus.Metric{probeTruncated("BGP/NeighborPaths/IPv6", truncated)}
+	for neighbor, count := range counter.srMap {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
-	for neighbor, count := range sr2Map {
+	for neighbor, count := range counter.sr2Map {
 		m = append(m, prometheus.MustNewConstMetric(BGPNeighborBestPaths, prometheus.GaugeValue, float64(count), neighbor.VDOM, neighbor.Source))
 	}
 
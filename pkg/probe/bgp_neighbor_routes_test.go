@@ -14,6 +14,7 @@
 package probe
 
 import (
+	"flag"
 	"strings"
 	"testing"
 
@@ -41,6 +42,9 @@ func TestBGPNeighborPathsIPv4(t *testing.T) {
     # TYPE fortigate_bgp_neighbor_ipv4_paths gauge
     fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.1",vdom="root"} 1
     fortigate_bgp_neighbor_ipv4_paths{neighbor_ip="10.0.0.2",vdom="root"} 2
+    # HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+    # TYPE fortigate_probe_truncated gauge
+    fortigate_probe_truncated{probe="BGP/NeighborPaths/IPv4"} 0
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -69,9 +73,27 @@ func TestBGPNeighborPathsIPv6(t *testing.T) {
     # TYPE fortigate_bgp_neighbor_ipv6_paths gauge
     fortigate_bgp_neighbor_ipv6_paths{neighbor_ip="::",vdom="root"} 1
     fortigate_bgp_neighbor_ipv6_paths{neighbor_ip="fd00::1",vdom="root"} 3
+    # HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+    # TYPE fortigate_probe_truncated gauge
+    fortigate_probe_truncated{probe="BGP/NeighborPaths/IPv6"} 0
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func BenchmarkBGPNeighborPathsIPv4(b *testing.B) {
+	if err := flag.Set("max-bgp-paths", "100000"); err != nil {
+		b.Fatalf("flag.Set failed: %v", err)
+	}
+	b.Cleanup(func() {
+		_ = flag.Set("max-bgp-paths", "10000")
+		config.MustReInit()
+	})
+	config.MustReInit()
+
+	// 3 paths in the fixture, 30000 paths in total
+	c := newScaledClient(b, "testdata/router-bgp-paths-v4.jsonnet", 10000)
+	benchmarkProbe(b, probeBGPNeighborPathsIPv4, c)
+}
@@ -0,0 +1,60 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+// newCMDBProbe returns a probe generating metrics from a CMDB table as
+// described by cp. Without any values configured the metric counts the rows
+// per label set, otherwise the values of rows sharing a label set are summed.
+func newCMDBProbe(cp config.CMDBProbe) probeFunc {
+	help := cp.Help
+	if help == "" {
+		help = "Rows of CMDB table " + cp.Path
+	}
+	rows := cp.Rows
+	if rows == "" {
+		rows = "results"
+	}
+	t := &tableProbe{
+		path:   "api/v2/cmdb/" + cp.Path,
+		vdom:   true,
+		rows:   rows,
+		labels: cp.Labels,
+	}
+	if len(cp.Values) == 0 {
+		t.metrics = append(t.metrics, tableMetric{name: cp.Name, help: help, scale: 1, vt: prometheus.GaugeValue})
+	}
+	names := []string{}
+	for name := range cp.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.metrics = append(t.metrics, tableMetric{
+			name:  cp.Name + "_" + name,
+			help:  help,
+			field: cp.Values[name],
+			scale: 1,
+			vt:    prometheus.GaugeValue,
+		})
+	}
+	return t.probe
+}
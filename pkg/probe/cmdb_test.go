@@ -0,0 +1,91 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestCMDBProbeCount(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/cmdb/firewall/address", "testdata/cmdb-firewall-address.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	cp := config.CMDBProbe{
+		Name:   "fortigate_firewall_address_objects",
+		Help:   "Number of firewall address objects",
+		Path:   "firewall/address",
+		Labels: map[string]string{"type": "type"},
+	}
+	if !testProbe(newCMDBProbe(cp), c, r) {
+		t.Errorf("newCMDBProbe() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firewall_address_objects Number of firewall address objects
+	# TYPE fortigate_firewall_address_objects gauge
+	fortigate_firewall_address_objects{type="fqdn",vdom="root"} 1
+	fortigate_firewall_address_objects{type="ipmask",vdom="guest"} 1
+	fortigate_firewall_address_objects{type="ipmask",vdom="root"} 2
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestCMDBProbeValues(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/cmdb/firewall/address", "testdata/cmdb-firewall-address.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	cp := config.CMDBProbe{
+		Name: "fortigate_firewall_address",
+		Path: "firewall/address",
+		Labels: map[string]string{
+			"name":      "name",
+			"interface": "associated-interface",
+		},
+		Values: map[string]string{
+			"cache_ttl_seconds": "cache-ttl",
+			"fabric_object":     "fabric-object",
+		},
+	}
+	if !testProbe(newCMDBProbe(cp), c, r) {
+		t.Errorf("newCMDBProbe() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firewall_address_cache_ttl_seconds Rows of CMDB table firewall/address
+	# TYPE fortigate_firewall_address_cache_ttl_seconds gauge
+	fortigate_firewall_address_cache_ttl_seconds{interface="",name="all",vdom="guest"} 0
+	fortigate_firewall_address_cache_ttl_seconds{interface="",name="all",vdom="root"} 0
+	fortigate_firewall_address_cache_ttl_seconds{interface="",name="login.microsoftonline.com",vdom="root"} 300
+	fortigate_firewall_address_cache_ttl_seconds{interface="internal",name="lan-net",vdom="root"} 0
+	# HELP fortigate_firewall_address_fabric_object Rows of CMDB table firewall/address
+	# TYPE fortigate_firewall_address_fabric_object gauge
+	fortigate_firewall_address_fabric_object{interface="",name="all",vdom="guest"} 0
+	fortigate_firewall_address_fabric_object{interface="",name="all",vdom="root"} 0
+	fortigate_firewall_address_fabric_object{interface="",name="login.microsoftonline.com",vdom="root"} 1
+	fortigate_firewall_address_fabric_object{interface="internal",name="lan-net",vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
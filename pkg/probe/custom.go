@@ -0,0 +1,287 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+// tableProbe generates metrics from the rows of an API response as declared
+// in the configuration. It is the engine behind the CMDB and custom probes.
+type tableProbe struct {
+	path  string
+	query string
+	// vdom queries all VDOMs and adds the "vdom" label
+	vdom bool
+	// rows is the dot-separated path to the rows in each response
+	rows string
+	// keyLabel treats rows as object and puts the keys into this label
+	keyLabel string
+	labels   map[string]string
+	metrics  []tableMetric
+}
+
+type tableMetric struct {
+	name  string
+	help  string
+	field string
+	scale float64
+	vt    prometheus.ValueType
+}
+
+func (t *tableProbe) probe(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	labelNames := []string{}
+	for l := range t.labels {
+		labelNames = append(labelNames, l)
+	}
+	sort.Strings(labelNames)
+
+	allLabels := []string{}
+	if t.vdom {
+		allLabels = append(allLabels, "vdom")
+	}
+	if t.keyLabel != "" {
+		allLabels = append(allLabels, t.keyLabel)
+	}
+	allLabels = append(allLabels, labelNames...)
+
+	query := t.query
+	if t.vdom {
+		if query != "" {
+			query += "&"
+		}
+		query += "vdom=*"
+	}
+
+	var res any
+	if err := c.Get(t.path, query, &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	responses := []any{res}
+	if t.vdom {
+		responses = jsonRows(res)
+	}
+
+	type series struct {
+		labels []string
+		values []float64
+	}
+	seriesMap := map[string]*series{}
+	seriesKeys := []string{}
+	for _, r := range responses {
+		vdom := jsonLabelValue(jsonLookup(r, "vdom"))
+		keys, rows := jsonKeyedRows(jsonLookup(r, t.rows), t.keyLabel != "")
+		for i, row := range rows {
+			lv := []string{}
+			if t.vdom {
+				lv = append(lv, vdom)
+			}
+			if t.keyLabel != "" {
+				lv = append(lv, keys[i])
+			}
+			for _, l := range labelNames {
+				lv = append(lv, jsonLabelValue(jsonLookup(row, t.labels[l])))
+			}
+			key := strings.Join(lv, "\xff")
+			s, ok := seriesMap[key]
+			if !ok {
+				s = &series{labels: lv, values: make([]float64, len(t.metrics))}
+				seriesMap[key] = s
+				seriesKeys = append(seriesKeys, key)
+			}
+			for j, tm := range t.metrics {
+				if tm.field == "" {
+					s.values[j]++
+					continue
+				}
+				f, ok := jsonValue(jsonLookup(row, tm.field))
+				if !ok {
+					log.Printf("Warning: Field %q of %q is not numeric", tm.field, t.path)
+					continue
+				}
+				s.values[j] += f * tm.scale
+			}
+		}
+	}
+
+	descs := []*prometheus.Desc{}
+	for _, tm := range t.metrics {
+		descs = append(descs, prometheus.NewDesc(tm.name, tm.help, allLabels, nil))
+	}
+
+	m := []prometheus.Metric{}
+	for _, key := range seriesKeys {
+		s := seriesMap[key]
+		for j, tm := range t.metrics {
+			m = append(m, prometheus.MustNewConstMetric(descs[j], tm.vt, s.values[j], s.labels...))
+		}
+	}
+	return m, true
+}
+
+// newCustomProbe returns a probe generating metrics from a monitor API
+// endpoint as described by cp.
+func newCustomProbe(cp config.CustomProbe) probeFunc {
+	rows := cp.Results
+	if rows == "" {
+		rows = "results"
+	}
+	t := &tableProbe{
+		path:     "api/v2/monitor/" + cp.Path,
+		query:    cp.Query,
+		vdom:     cp.VDOM,
+		rows:     rows,
+		keyLabel: cp.KeyLabel,
+		labels:   cp.Labels,
+	}
+	for _, cm := range cp.Metrics {
+		tm := tableMetric{
+			name:  cm.Name,
+			help:  cm.Help,
+			field: cm.Field,
+			scale: cm.Scale,
+			vt:    prometheus.GaugeValue,
+		}
+		if tm.help == "" {
+			tm.help = "Custom metric from " + t.path
+		}
+		if tm.scale == 0 {
+			tm.scale = 1
+		}
+		if cm.Type == "counter" {
+			tm.vt = prometheus.CounterValue
+		}
+		t.metrics = append(t.metrics, tm)
+	}
+	return t.probe
+}
+
+// jsonLookup walks a dot-separated path through decoded JSON, using numeric
+// path elements as array indices. It returns nil if the path does not exist.
+func jsonLookup(v any, path string) any {
+	if path == "" || path == "." {
+		return v
+	}
+	for _, p := range strings.Split(path, ".") {
+		switch t := v.(type) {
+		case map[string]any:
+			v = t[p]
+		case []any:
+			i, err := strconv.Atoi(p)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil
+			}
+			v = t[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// jsonRows returns the rows of a table, which is a single object for tables
+// like system/ha and a list of objects otherwise.
+func jsonRows(v any) []any {
+	switch t := v.(type) {
+	case []any:
+		return t
+	case map[string]any:
+		return []any{t}
+	}
+	return nil
+}
+
+// jsonKeyedRows returns the rows of a table like jsonRows, or if keyed is set
+// the values of an object of rows together with their sorted keys.
+func jsonKeyedRows(v any, keyed bool) ([]string, []any) {
+	if !keyed {
+		return nil, jsonRows(v)
+	}
+	o, ok := v.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	keys := []string{}
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := []any{}
+	for _, k := range keys {
+		rows = append(rows, o[k])
+	}
+	return keys, rows
+}
+
+// jsonLabelValue formats a JSON value for use as label value. Lists of
+// references like [{"name": "port1"}, {"name": "port2"}] are joined by comma.
+func jsonLabelValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case []any:
+		names := []string{}
+		for _, e := range t {
+			if o, ok := e.(map[string]any); ok {
+				names = append(names, jsonLabelValue(o["name"]))
+			} else {
+				names = append(names, jsonLabelValue(e))
+			}
+		}
+		return strings.Join(names, ",")
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jsonValue converts a JSON value to a metric value. Besides numbers it
+// understands booleans and the "enable"/"disable" and "up"/"down" strings
+// FortiOS uses for options and states.
+func jsonValue(v any) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case bool:
+		if t {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		switch t {
+		case "enable", "up":
+			return 1, true
+		case "disable", "down":
+			return 0, true
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestCustomProbeKeyed(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/link-monitor", "testdata/link-monitor.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	cp := config.CustomProbe{
+		Name:     "LinkMonitor",
+		Path:     "system/link-monitor",
+		VDOM:     true,
+		Results:  "results.wan-mon",
+		KeyLabel: "interface",
+		Metrics: []config.CustomMetric{
+			{Name: "custom_link_up", Help: "Link status", Field: "status"},
+			{Name: "custom_link_latency_seconds", Help: "Link latency", Field: "latency", Scale: 0.001},
+			{Name: "custom_link_packets_sent_total", Help: "Packets sent", Field: "packet_sent", Type: "counter"},
+		},
+	}
+	if !testProbe(newCustomProbe(cp), c, r) {
+		t.Errorf("newCustomProbe() returned non-success")
+	}
+
+	em := `
+	# HELP custom_link_latency_seconds Link latency
+	# TYPE custom_link_latency_seconds gauge
+	custom_link_latency_seconds{interface="wan1",vdom="root"} 0.006810200214385986
+	# HELP custom_link_packets_sent_total Packets sent
+	# TYPE custom_link_packets_sent_total counter
+	custom_link_packets_sent_total{interface="wan1",vdom="root"} 278878
+	# HELP custom_link_up Link status
+	# TYPE custom_link_up gauge
+	custom_link_up{interface="wan1",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestCustomProbeRows(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/wifi/client", "testdata/wifi-client.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	cp := config.CustomProbe{
+		Name:   "WifiSSID",
+		Path:   "wifi/client",
+		Query:  "start=0&count=1000",
+		VDOM:   true,
+		Labels: map[string]string{"ssid": "ssid", "band": "health.band.value"},
+		Metrics: []config.CustomMetric{
+			{Name: "custom_wifi_clients", Help: "Connected clients"},
+			{Name: "custom_wifi_data_rate_bps", Field: "data_rate_bps"},
+		},
+	}
+	if !testProbe(newCustomProbe(cp), c, r) {
+		t.Errorf("newCustomProbe() returned non-success")
+	}
+
+	em := `
+	# HELP custom_wifi_clients Connected clients
+	# TYPE custom_wifi_clients gauge
+	custom_wifi_clients{band="24ghz",ssid="example-SSID",vdom="root"} 1
+	custom_wifi_clients{band="5ghz",ssid="example-SSID",vdom="root"} 1
+	# HELP custom_wifi_data_rate_bps Custom metric from api/v2/monitor/wifi/client
+	# TYPE custom_wifi_data_rate_bps gauge
+	custom_wifi_data_rate_bps{band="24ghz",ssid="example-SSID",vdom="root"} 1e+06
+	custom_wifi_data_rate_bps{band="5ghz",ssid="example-SSID",vdom="root"} 1.3e+08
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type ExtenderModem struct {
+	ConnectStatus string  `json:"connect_status"`
+	Carrier       string  `json:"carrier"`
+	Service       string  `json:"service"`
+	RSSI          float64 `json:"rssi"`
+	RSRP          float64 `json:"rsrp"`
+	SINR          float64 `json:"sinr"`
+	SentBytes     float64 `json:"data_usage_sent"`
+	ReceivedBytes float64 `json:"data_usage_received"`
+}
+
+type Extender struct {
+	Name    string         `json:"name"`
+	Serial  string         `json:"serial"`
+	Model   string         `json:"model"`
+	Version string         `json:"version"`
+	Uptime  float64        `json:"uptime"`
+	Modem1  *ExtenderModem `json:"modem1"`
+	Modem2  *ExtenderModem `json:"modem2"`
+}
+
+type ExtenderResponse struct {
+	Results []Extender `json:"results"`
+	VDOM    string     `json:"vdom"`
+}
+
+func probeExtenderStatus(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mInfo = prometheus.NewDesc(
+			"fortigate_extender_info",
+			"Infos about a managed FortiExtender",
+			[]string{"vdom", "name", "serial", "model", "version"}, nil,
+		)
+		mUptime = prometheus.NewDesc(
+			"fortigate_extender_uptime_seconds",
+			"Time since the FortiExtender was started",
+			[]string{"vdom", "name"}, nil,
+		)
+		mModemInfo = prometheus.NewDesc(
+			"fortigate_extender_modem_info",
+			"Carrier and service of the modem",
+			[]string{"vdom", "name", "modem", "carrier", "service"}, nil,
+		)
+		mConnected = prometheus.NewDesc(
+			"fortigate_extender_modem_connected",
+			"Whether the modem is connected to the mobile network",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+		mRSSI = prometheus.NewDesc(
+			"fortigate_extender_modem_rssi_dBm",
+			"Received signal strength indicator of the modem",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+		mRSRP = prometheus.NewDesc(
+			"fortigate_extender_modem_rsrp_dBm",
+			"Reference signal received power of the modem",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+		mSINR = prometheus.NewDesc(
+			"fortigate_extender_modem_sinr_dB",
+			"Signal to interference plus noise ratio of the modem",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+		mSent = prometheus.NewDesc(
+			"fortigate_extender_modem_sent_bytes_total",
+			"Data sent by the modem as counted by the FortiExtender",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+		mReceived = prometheus.NewDesc(
+			"fortigate_extender_modem_received_bytes_total",
+			"Data received by the modem as counted by the FortiExtender",
+			[]string{"vdom", "name", "modem"}, nil,
+		)
+	)
+
+	var res []ExtenderResponse
+	if err := c.Get("api/v2/monitor/extender-controller/extender", "vdom=*", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		for _, e := range r.Results {
+			m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, r.VDOM, e.Name, e.Serial, e.Model, e.Version))
+			m = append(m, prometheus.MustNewConstMetric(mUptime, prometheus.GaugeValue, e.Uptime, r.VDOM, e.Name))
+
+			for modem, s := range map[string]*ExtenderModem{"modem1": e.Modem1, "modem2": e.Modem2} {
+				// Single modem models do not report a second modem
+				if s == nil {
+					continue
+				}
+				connected := 0.0
+				if s.ConnectStatus == "CONN_STATE_CONNECTED" {
+					connected = 1
+				}
+				m = append(m, prometheus.MustNewConstMetric(mModemInfo, prometheus.GaugeValue, 1, r.VDOM, e.Name, modem, s.Carrier, s.Service))
+				m = append(m, prometheus.MustNewConstMetric(mConnected, prometheus.GaugeValue, connected, r.VDOM, e.Name, modem))
+				m = append(m, prometheus.MustNewConstMetric(mRSSI, prometheus.GaugeValue, s.RSSI, r.VDOM, e.Name, modem))
+				m = append(m, prometheus.MustNewConstMetric(mRSRP, prometheus.GaugeValue, s.RSRP, r.VDOM, e.Name, modem))
+				m = append(m, prometheus.MustNewConstMetric(mSINR, prometheus.GaugeValue, s.SINR, r.VDOM, e.Name, modem))
+				m = append(m, prometheus.MustNewConstMetric(mSent, prometheus.CounterValue, s.SentBytes, r.VDOM, e.Name, modem))
+				m = append(m, prometheus.MustNewConstMetric(mReceived, prometheus.CounterValue, s.ReceivedBytes, r.VDOM, e.Name, modem))
+			}
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,81 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestExtenderStatus(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/extender-controller/extender", "testdata/extender-controller-extender.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeExtenderStatus, c, r) {
+		t.Errorf("probeExtenderStatus() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_extender_info Infos about a managed FortiExtender
+	# TYPE fortigate_extender_info gauge
+	fortigate_extender_info{model="FX201E",name="branch1-lte",serial="FX201E5919000001",vdom="root",version="v7.0.4"} 1
+	fortigate_extender_info{model="FX511G",name="branch2-5g",serial="FX511G5922000002",vdom="root",version="v7.4.1"} 1
+	# HELP fortigate_extender_modem_connected Whether the modem is connected to the mobile network
+	# TYPE fortigate_extender_modem_connected gauge
+	fortigate_extender_modem_connected{modem="modem1",name="branch1-lte",vdom="root"} 1
+	fortigate_extender_modem_connected{modem="modem1",name="branch2-5g",vdom="root"} 1
+	fortigate_extender_modem_connected{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_modem_info Carrier and service of the modem
+	# TYPE fortigate_extender_modem_info gauge
+	fortigate_extender_modem_info{carrier="",modem="modem2",name="branch1-lte",service="",vdom="root"} 1
+	fortigate_extender_modem_info{carrier="Telekom.de",modem="modem1",name="branch1-lte",service="LTE",vdom="root"} 1
+	fortigate_extender_modem_info{carrier="Vodafone.de",modem="modem1",name="branch2-5g",service="5G NSA",vdom="root"} 1
+	# HELP fortigate_extender_modem_received_bytes_total Data received by the modem as counted by the FortiExtender
+	# TYPE fortigate_extender_modem_received_bytes_total counter
+	fortigate_extender_modem_received_bytes_total{modem="modem1",name="branch1-lte",vdom="root"} 5.36870912e+09
+	fortigate_extender_modem_received_bytes_total{modem="modem1",name="branch2-5g",vdom="root"} 4096
+	fortigate_extender_modem_received_bytes_total{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_modem_rsrp_dBm Reference signal received power of the modem
+	# TYPE fortigate_extender_modem_rsrp_dBm gauge
+	fortigate_extender_modem_rsrp_dBm{modem="modem1",name="branch1-lte",vdom="root"} -89
+	fortigate_extender_modem_rsrp_dBm{modem="modem1",name="branch2-5g",vdom="root"} -82
+	fortigate_extender_modem_rsrp_dBm{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_modem_rssi_dBm Received signal strength indicator of the modem
+	# TYPE fortigate_extender_modem_rssi_dBm gauge
+	fortigate_extender_modem_rssi_dBm{modem="modem1",name="branch1-lte",vdom="root"} -61
+	fortigate_extender_modem_rssi_dBm{modem="modem1",name="branch2-5g",vdom="root"} -55
+	fortigate_extender_modem_rssi_dBm{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_modem_sent_bytes_total Data sent by the modem as counted by the FortiExtender
+	# TYPE fortigate_extender_modem_sent_bytes_total counter
+	fortigate_extender_modem_sent_bytes_total{modem="modem1",name="branch1-lte",vdom="root"} 1.073741824e+09
+	fortigate_extender_modem_sent_bytes_total{modem="modem1",name="branch2-5g",vdom="root"} 2048
+	fortigate_extender_modem_sent_bytes_total{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_modem_sinr_dB Signal to interference plus noise ratio of the modem
+	# TYPE fortigate_extender_modem_sinr_dB gauge
+	fortigate_extender_modem_sinr_dB{modem="modem1",name="branch1-lte",vdom="root"} 14.5
+	fortigate_extender_modem_sinr_dB{modem="modem1",name="branch2-5g",vdom="root"} 21
+	fortigate_extender_modem_sinr_dB{modem="modem2",name="branch1-lte",vdom="root"} 0
+	# HELP fortigate_extender_uptime_seconds Time since the FortiExtender was started
+	# TYPE fortigate_extender_uptime_seconds gauge
+	fortigate_extender_uptime_seconds{name="branch1-lte",vdom="root"} 864000
+	fortigate_extender_uptime_seconds{name="branch2-5g",vdom="root"} 3600
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
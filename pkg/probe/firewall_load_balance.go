@@ -20,6 +20,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
@@ -87,26 +88,13 @@ func probeFirewallLoadBalance(c http.FortiHTTP, meta *TargetMetadata) ([]prometh
 		RealServers []RealServer `json:"list"`
 	}
 
-	type LoadBalanceResponse struct {
-		HTTPMethod string          `json:"http_method"`
-		Results    []VirtualServer `json:"results"`
-		VDOM       string          `json:"vdom"`
-		Path       string          `json:"path"`
-		Name       string          `json:"name"`
-		Status     string          `json:"status"`
-		Serial     string          `json:"serial"`
-		Version    string          `json:"version"`
-		Build      int64           `json:"build"`
-	}
-
-	// Consider implementing pagination to remove this limit of 1000 entries
-	var rs []LoadBalanceResponse
-	if err := c.Get("api/v2/monitor/firewall/load-balance", "vdom=*&start=0&count=1000", &rs); err != nil {
+	rs, truncated, err := http.GetAll[VirtualServer](c, "api/v2/monitor/firewall/load-balance", "vdom=*", config.GetConfig().MaxListEntries)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
 
-	m := []prometheus.Metric{}
+	m := []prometheus.Metric{probeTruncated("Firewall/LoadBalance", truncated)}
 
 	for _, r := range rs {
 		for _, virtualServer := range r.Results {
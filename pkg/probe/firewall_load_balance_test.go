@@ -19,9 +19,15 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 )
 
 func TestFirewallLoadBalance(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/firewall/load-balance?vdom=*&start=0&count=1000", "testdata/fw-loadbalancers.jsonnet")
 	r := prometheus.NewPedanticRegistry()
@@ -85,6 +91,9 @@ func TestFirewallLoadBalance(t *testing.T) {
 	# HELP fortigate_lb_virtual_server_info Info metric regarding virtual servers
 	# TYPE fortigate_lb_virtual_server_info gauge
 	fortigate_lb_virtual_server_info{ip="169.254.1.1",name="LB-EXAMPLE",port="80",type="http",vdom="root"} 1
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Firewall/LoadBalance"} 0
 	`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -45,6 +45,16 @@ func probeFirewallPolicies(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Me
 			"Number of active sessions for a policy",
 			[]string{"vdom", "protocol", "name", "uuid", "id"}, nil,
 		)
+		mOffloadBytes = prometheus.NewDesc(
+			"fortigate_policy_offload_bytes_total",
+			"Number of bytes that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)",
+			[]string{"vdom", "protocol", "name", "uuid", "id", "offload"}, nil,
+		)
+		mOffloadPackets = prometheus.NewDesc(
+			"fortigate_policy_offload_packets_total",
+			"Number of packets that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)",
+			[]string{"vdom", "protocol", "name", "uuid", "id", "offload"}, nil,
+		)
 	)
 
 	type pStats struct {
@@ -165,6 +175,12 @@ func probeFirewallPolicies(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Me
 			prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, s.Bytes, ps.VDOM, proto, name, s.UUID, id),
 			prometheus.MustNewConstMetric(mPackets, prometheus.CounterValue, s.Packets, ps.VDOM, proto, name, s.UUID, id),
 			prometheus.MustNewConstMetric(mActiveSessions, prometheus.GaugeValue, s.ActiveSessions, ps.VDOM, proto, name, s.UUID, id),
+			prometheus.MustNewConstMetric(mOffloadBytes, prometheus.CounterValue, s.ASICBytes, ps.VDOM, proto, name, s.UUID, id, "asic"),
+			prometheus.MustNewConstMetric(mOffloadBytes, prometheus.CounterValue, s.NTurboBytes, ps.VDOM, proto, name, s.UUID, id, "nturbo"),
+			prometheus.MustNewConstMetric(mOffloadBytes, prometheus.CounterValue, s.SoftwareBytes, ps.VDOM, proto, name, s.UUID, id, "software"),
+			prometheus.MustNewConstMetric(mOffloadPackets, prometheus.CounterValue, s.ASICPackets, ps.VDOM, proto, name, s.UUID, id, "asic"),
+			prometheus.MustNewConstMetric(mOffloadPackets, prometheus.CounterValue, s.NTurboPackets, ps.VDOM, proto, name, s.UUID, id, "nturbo"),
+			prometheus.MustNewConstMetric(mOffloadPackets, prometheus.CounterValue, s.SoftwarePackets, ps.VDOM, proto, name, s.UUID, id, "software"),
 		}
 		return m
 	}
@@ -60,6 +60,52 @@ func TestFirewallPoliciesPre64(t *testing.T) {
 	fortigate_policy_hit_count_total{id="1",name="",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 4662
 	fortigate_policy_hit_count_total{id="1",name="ipv6 policy",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_offload_bytes_total Number of bytes that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)
+	# TYPE fortigate_policy_offload_bytes_total counter
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.10815705e+08
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2.3643317e+07
+	fortigate_policy_offload_bytes_total{id="1",name="ipv6 policy",offload="asic",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="ipv6 policy",offload="nturbo",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="ipv6 policy",offload="software",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="asic",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="nturbo",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="software",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_offload_packets_total Number of packets that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)
+	# TYPE fortigate_policy_offload_packets_total counter
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="1",name="",offload="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 706553
+	fortigate_policy_offload_packets_total{id="1",name="",offload="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="1",name="",offload="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 86253
+	fortigate_policy_offload_packets_total{id="1",name="ipv6 policy",offload="asic",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="1",name="ipv6 policy",offload="nturbo",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="1",name="ipv6 policy",offload="software",protocol="ipv6",uuid="4a2e2fe4-9e9d-51ea-75b1-b5b486b12192",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="asic",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="nturbo",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="software",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
 	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy
 	# TYPE fortigate_policy_packets_total counter
 	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
@@ -116,6 +162,58 @@ func TestFirewallPolicies(t *testing.T) {
 	fortigate_policy_hit_count_total{id="1",name="",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 11000
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
 	fortigate_policy_hit_count_total{id="2",name="ping",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_offload_bytes_total Number of bytes that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)
+	# TYPE fortigate_policy_offload_bytes_total counter
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5.10815705e+08
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="asic",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 5000
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="nturbo",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 7000
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 2.3643317e+07
+	fortigate_policy_offload_bytes_total{id="1",name="",offload="software",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 3000
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="asic",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="asic",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="nturbo",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="nturbo",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="software",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_bytes_total{id="2",name="ping",offload="software",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	# HELP fortigate_policy_offload_packets_total Number of packets that has passed through a policy by handling (asic - offloaded to NPU, nturbo - accelerated by NTurbo, software - handled by the CPU)
+	# TYPE fortigate_policy_offload_packets_total counter
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="asic",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="nturbo",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv4",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="0",name="Implicit Deny",offload="software",protocol="ipv6",uuid="",vdom="root"} 0
+	fortigate_policy_offload_packets_total{id="1",name="",offload="asic",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 706553
+	fortigate_policy_offload_packets_total{id="1",name="",offload="asic",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 6000
+	fortigate_policy_offload_packets_total{id="1",name="",offload="nturbo",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="1",name="",offload="nturbo",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 8000
+	fortigate_policy_offload_packets_total{id="1",name="",offload="software",protocol="ipv4",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 86253
+	fortigate_policy_offload_packets_total{id="1",name="",offload="software",protocol="ipv6",uuid="078f184c-9e9d-51ea-9fbb-66c20957b9c0",vdom="FG-traffic"} 4000
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="asic",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="asic",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="nturbo",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="nturbo",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="software",protocol="ipv4",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
+	fortigate_policy_offload_packets_total{id="2",name="ping",offload="software",protocol="ipv6",uuid="24843c52-9e9d-51ea-b838-3500a9e54b2e",vdom="FG-traffic"} 0
 	# HELP fortigate_policy_packets_total Number of packets that has passed through a policy
 	# TYPE fortigate_policy_packets_total counter
 	fortigate_policy_packets_total{id="0",name="Implicit Deny",protocol="ipv4",uuid="",vdom="FG-traffic"} 0
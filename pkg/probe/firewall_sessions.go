@@ -0,0 +1,234 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type SessionSummary struct {
+	MatchedCount   float64 `json:"matched_count"`
+	SetupRate      float64 `json:"setup_rate"`
+	NPUSessions    float64 `json:"npu_session_count"`
+	NTurboSessions float64 `json:"nturbo_session_count"`
+}
+
+type Session struct {
+	Source      string `json:"saddr"`
+	Destination string `json:"daddr"`
+	Apps        []struct {
+		Name string `json:"name"`
+	} `json:"apps"`
+}
+
+type SessionResponse struct {
+	Results struct {
+		Details []Session      `json:"details"`
+		Summary SessionSummary `json:"summary"`
+	} `json:"results"`
+	VDOM string `json:"vdom"`
+}
+
+// sessionProtocols are counted separately, all other sessions are reported as protocol "other"
+var sessionProtocols = []string{"tcp", "udp", "icmp"}
+
+type sessionTop struct {
+	sources      map[string]int
+	destinations map[string]int
+	applications map[string]int
+}
+
+func probeFirewallSessions(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mSessions = prometheus.NewDesc(
+			"fortigate_firewall_sessions",
+			"Number of sessions in the session table",
+			[]string{"vdom"}, nil,
+		)
+		mSetupRate = prometheus.NewDesc(
+			"fortigate_firewall_session_setup_rate",
+			"Number of sessions set up per second",
+			[]string{"vdom"}, nil,
+		)
+		mProtocol = prometheus.NewDesc(
+			"fortigate_firewall_sessions_by_protocol",
+			"Number of sessions in the session table per protocol",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mOffload = prometheus.NewDesc(
+			"fortigate_firewall_sessions_by_offload",
+			"Number of sessions in the session table per handling (npu - offloaded to NPU, nturbo - accelerated by NTurbo, kernel - handled by the CPU)",
+			[]string{"vdom", "offload"}, nil,
+		)
+		mTopSource = prometheus.NewDesc(
+			"fortigate_firewall_session_top_source_sessions",
+			"Number of sessions of the source addresses with the most sessions",
+			[]string{"vdom", "source"}, nil,
+		)
+		mTopDestination = prometheus.NewDesc(
+			"fortigate_firewall_session_top_destination_sessions",
+			"Number of sessions of the destination addresses with the most sessions",
+			[]string{"vdom", "destination"}, nil,
+		)
+		mTopApplication = prometheus.NewDesc(
+			"fortigate_firewall_session_top_application_sessions",
+			"Number of sessions of the applications with the most sessions",
+			[]string{"vdom", "application"}, nil,
+		)
+	)
+
+	var summary []SessionResponse
+	if err := c.Get("api/v2/monitor/firewall/session", "vdom=*&summary=true&count=1", &summary); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	totals := map[string]float64{}
+	for _, r := range summary {
+		s := r.Results.Summary
+		totals[r.VDOM] = s.MatchedCount
+		m = append(m, prometheus.MustNewConstMetric(mSessions, prometheus.GaugeValue, s.MatchedCount, r.VDOM))
+		m = append(m, prometheus.MustNewConstMetric(mSetupRate, prometheus.GaugeValue, s.SetupRate, r.VDOM))
+		m = append(m, prometheus.MustNewConstMetric(mOffload, prometheus.GaugeValue, s.NPUSessions, r.VDOM, "npu"))
+		m = append(m, prometheus.MustNewConstMetric(mOffload, prometheus.GaugeValue, s.NTurboSessions, r.VDOM, "nturbo"))
+		m = append(m, prometheus.MustNewConstMetric(mOffload, prometheus.GaugeValue, max(s.MatchedCount-s.NPUSessions-s.NTurboSessions, 0), r.VDOM, "kernel"))
+	}
+
+	others := map[string]float64{}
+	for vdom, total := range totals {
+		others[vdom] = total
+	}
+	for _, protocol := range sessionProtocols {
+		var res []SessionResponse
+		if err := c.Get("api/v2/monitor/firewall/session", "vdom=*&summary=true&count=1&protocol="+protocol, &res); err != nil {
+			log.Printf("Error: %v", err)
+			return nil, false
+		}
+		for _, r := range res {
+			m = append(m, prometheus.MustNewConstMetric(mProtocol, prometheus.GaugeValue, r.Results.Summary.MatchedCount, r.VDOM, protocol))
+			others[r.VDOM] -= r.Results.Summary.MatchedCount
+		}
+	}
+	for vdom, other := range others {
+		// The counts are taken one after another and may not add up exactly
+		m = append(m, prometheus.MustNewConstMetric(mProtocol, prometheus.GaugeValue, max(other, 0), vdom, "other"))
+	}
+
+	savedConfig := config.GetConfig()
+	if savedConfig.MaxSessions == 0 {
+		return m, true
+	}
+
+	tops, truncated, err := fetchSessionTop(c, savedConfig.MaxSessions)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more sessions than maximum (%d) allowed, top sessions are based on a part of them", savedConfig.MaxSessions)
+	}
+	m = append(m, probeTruncated("Firewall/Sessions", truncated))
+	for vdom, top := range tops {
+		for _, k := range topKeys(top.sources, savedConfig.TopSessions) {
+			m = append(m, prometheus.MustNewConstMetric(mTopSource, prometheus.GaugeValue, float64(top.sources[k]), vdom, k))
+		}
+		for _, k := range topKeys(top.destinations, savedConfig.TopSessions) {
+			m = append(m, prometheus.MustNewConstMetric(mTopDestination, prometheus.GaugeValue, float64(top.destinations[k]), vdom, k))
+		}
+		for _, k := range topKeys(top.applications, savedConfig.TopSessions) {
+			m = append(m, prometheus.MustNewConstMetric(mTopApplication, prometheus.GaugeValue, float64(top.applications[k]), vdom, k))
+		}
+	}
+
+	return m, true
+}
+
+// fetchSessionTop counts the sessions per source, destination and application
+// of at most limit sessions per VDOM, which are fetched page by page.
+func fetchSessionTop(c http.FortiHTTP, limit int) (map[string]*sessionTop, bool, error) {
+	tops := map[string]*sessionTop{}
+	fetched := map[string]int{}
+	matched := map[string]float64{}
+	for start := 0; start < limit; {
+		count := min(http.ListPageSize, limit-start)
+		var page []SessionResponse
+		q := fmt.Sprintf("vdom=*&summary=true&start=%d&count=%d", start, count)
+		if err := c.Get("api/v2/monitor/firewall/session", q, &page); err != nil {
+			return nil, false, err
+		}
+
+		more := false
+		for _, r := range page {
+			top, ok := tops[r.VDOM]
+			if !ok {
+				top = &sessionTop{
+					sources:      map[string]int{},
+					destinations: map[string]int{},
+					applications: map[string]int{},
+				}
+				tops[r.VDOM] = top
+			}
+			for _, s := range r.Results.Details {
+				top.sources[s.Source]++
+				top.destinations[s.Destination]++
+				for _, app := range s.Apps {
+					top.applications[app.Name]++
+				}
+			}
+			fetched[r.VDOM] += len(r.Results.Details)
+			matched[r.VDOM] = r.Results.Summary.MatchedCount
+			if len(r.Results.Details) == count && float64(fetched[r.VDOM]) < matched[r.VDOM] {
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+		start += count
+	}
+
+	truncated := false
+	for vdom, n := range fetched {
+		if float64(n) < matched[vdom] {
+			truncated = true
+		}
+	}
+	return tops, truncated, nil
+}
+
+// topKeys returns the n keys with the highest counts, ties sorted by name
+func topKeys(counts map[string]int, n int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
@@ -0,0 +1,129 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func newSessionClient() *fakeClient {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/firewall/session?protocol=tcp", "testdata/firewall-session-tcp.jsonnet")
+	c.prepare("api/v2/monitor/firewall/session?protocol=udp", "testdata/firewall-session-udp.jsonnet")
+	c.prepare("api/v2/monitor/firewall/session?protocol=icmp", "testdata/firewall-session-icmp.jsonnet")
+	c.prepare("api/v2/monitor/firewall/session?count=1", "testdata/firewall-session-summary.jsonnet")
+	c.prepare("api/v2/monitor/firewall/session?start=0", "testdata/firewall-session.jsonnet")
+	return c
+}
+
+func TestFirewallSessions(t *testing.T) {
+	config.MustReInit()
+	c := newSessionClient()
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeFirewallSessions, c, r) {
+		t.Errorf("probeFirewallSessions() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firewall_session_setup_rate Number of sessions set up per second
+	# TYPE fortigate_firewall_session_setup_rate gauge
+	fortigate_firewall_session_setup_rate{vdom="FG-traffic"} 1
+	fortigate_firewall_session_setup_rate{vdom="root"} 35
+	# HELP fortigate_firewall_sessions Number of sessions in the session table
+	# TYPE fortigate_firewall_sessions gauge
+	fortigate_firewall_sessions{vdom="FG-traffic"} 3
+	fortigate_firewall_sessions{vdom="root"} 1500
+	# HELP fortigate_firewall_sessions_by_offload Number of sessions in the session table per handling (npu - offloaded to NPU, nturbo - accelerated by NTurbo, kernel - handled by the CPU)
+	# TYPE fortigate_firewall_sessions_by_offload gauge
+	fortigate_firewall_sessions_by_offload{offload="kernel",vdom="FG-traffic"} 3
+	fortigate_firewall_sessions_by_offload{offload="kernel",vdom="root"} 500
+	fortigate_firewall_sessions_by_offload{offload="npu",vdom="FG-traffic"} 0
+	fortigate_firewall_sessions_by_offload{offload="npu",vdom="root"} 900
+	fortigate_firewall_sessions_by_offload{offload="nturbo",vdom="FG-traffic"} 0
+	fortigate_firewall_sessions_by_offload{offload="nturbo",vdom="root"} 100
+	# HELP fortigate_firewall_sessions_by_protocol Number of sessions in the session table per protocol
+	# TYPE fortigate_firewall_sessions_by_protocol gauge
+	fortigate_firewall_sessions_by_protocol{protocol="icmp",vdom="FG-traffic"} 0
+	fortigate_firewall_sessions_by_protocol{protocol="icmp",vdom="root"} 30
+	fortigate_firewall_sessions_by_protocol{protocol="other",vdom="FG-traffic"} 0
+	fortigate_firewall_sessions_by_protocol{protocol="other",vdom="root"} 20
+	fortigate_firewall_sessions_by_protocol{protocol="tcp",vdom="FG-traffic"} 2
+	fortigate_firewall_sessions_by_protocol{protocol="tcp",vdom="root"} 1200
+	fortigate_firewall_sessions_by_protocol{protocol="udp",vdom="FG-traffic"} 1
+	fortigate_firewall_sessions_by_protocol{protocol="udp",vdom="root"} 250
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestFirewallSessionsTop(t *testing.T) {
+	if err := flag.Set("max-sessions", "10"); err != nil {
+		t.Fatalf("flag.Set failed: %v", err)
+	}
+	if err := flag.Set("top-sessions", "2"); err != nil {
+		t.Fatalf("flag.Set failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = flag.Set("max-sessions", "0")
+		_ = flag.Set("top-sessions", "10")
+		config.MustReInit()
+	})
+	config.MustReInit()
+
+	c := newSessionClient()
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeFirewallSessions, c, r) {
+		t.Errorf("probeFirewallSessions() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firewall_session_top_application_sessions Number of sessions of the applications with the most sessions
+	# TYPE fortigate_firewall_session_top_application_sessions gauge
+	fortigate_firewall_session_top_application_sessions{application="DNS",vdom="FG-traffic"} 2
+	fortigate_firewall_session_top_application_sessions{application="DNS",vdom="root"} 4
+	fortigate_firewall_session_top_application_sessions{application="HTTPS.BROWSER",vdom="root"} 3
+	# HELP fortigate_firewall_session_top_destination_sessions Number of sessions of the destination addresses with the most sessions
+	# TYPE fortigate_firewall_session_top_destination_sessions gauge
+	fortigate_firewall_session_top_destination_sessions{destination="1.1.1.1",vdom="root"} 4
+	fortigate_firewall_session_top_destination_sessions{destination="10.30.0.1",vdom="FG-traffic"} 2
+	fortigate_firewall_session_top_destination_sessions{destination="203.0.113.5",vdom="FG-traffic"} 1
+	fortigate_firewall_session_top_destination_sessions{destination="8.8.8.8",vdom="root"} 4
+	# HELP fortigate_firewall_session_top_source_sessions Number of sessions of the source addresses with the most sessions
+	# TYPE fortigate_firewall_session_top_source_sessions gauge
+	fortigate_firewall_session_top_source_sessions{source="10.0.0.5",vdom="root"} 5
+	fortigate_firewall_session_top_source_sessions{source="10.0.0.6",vdom="root"} 3
+	fortigate_firewall_session_top_source_sessions{source="10.30.0.10",vdom="FG-traffic"} 2
+	fortigate_firewall_session_top_source_sessions{source="10.30.0.11",vdom="FG-traffic"} 1
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Firewall/Sessions"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em),
+		"fortigate_firewall_session_top_application_sessions",
+		"fortigate_firewall_session_top_destination_sessions",
+		"fortigate_firewall_session_top_source_sessions",
+		"fortigate_probe_truncated"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
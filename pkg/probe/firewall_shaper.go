@@ -0,0 +1,135 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type Shaper struct {
+	Name                string  `json:"name"`
+	GuaranteedBandwidth float64 `json:"guaranteed_bandwidth"`
+	MaximumBandwidth    float64 `json:"maximum_bandwidth"`
+	CurrentBandwidth    float64 `json:"current_bandwidth"`
+	BandwidthUnit       string  `json:"bandwidth_unit"`
+	DroppedBytes        float64 `json:"dropped_bytes"`
+}
+
+type ShaperResponse struct {
+	Results []Shaper `json:"results"`
+	VDOM    string   `json:"vdom"`
+}
+
+type PerIPShaper struct {
+	Name             string  `json:"name"`
+	MaxBandwidth     float64 `json:"max_bandwidth"`
+	CurrentBandwidth float64 `json:"current_bandwidth"`
+	BandwidthUnit    string  `json:"bandwidth_unit"`
+	DroppedBytes     float64 `json:"dropped_bytes"`
+}
+
+type PerIPShaperResponse struct {
+	Results []PerIPShaper `json:"results"`
+	VDOM    string        `json:"vdom"`
+}
+
+// shaperBandwidthScale returns the factor converting bandwidths in the given
+// unit to bits/s. FortiOS defaults to kbps when no unit is configured.
+func shaperBandwidthScale(unit string) float64 {
+	switch unit {
+	case "mbps":
+		return 1000 * 1000
+	case "gbps":
+		return 1000 * 1000 * 1000
+	default:
+		return 1000
+	}
+}
+
+func probeFirewallShaper(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mCurrent = prometheus.NewDesc(
+			"fortigate_shaper_current_bandwidth_bps",
+			"Current bandwidth of the traffic shaper in bits/s",
+			[]string{"vdom", "name"}, nil,
+		)
+		mGuaranteed = prometheus.NewDesc(
+			"fortigate_shaper_guaranteed_bandwidth_bps",
+			"Configured guaranteed bandwidth of the traffic shaper in bits/s",
+			[]string{"vdom", "name"}, nil,
+		)
+		mMaximum = prometheus.NewDesc(
+			"fortigate_shaper_maximum_bandwidth_bps",
+			"Configured maximum bandwidth of the traffic shaper in bits/s",
+			[]string{"vdom", "name"}, nil,
+		)
+		mDropped = prometheus.NewDesc(
+			"fortigate_shaper_dropped_bytes_total",
+			"Total number of bytes dropped by the traffic shaper",
+			[]string{"vdom", "name"}, nil,
+		)
+		mPerIPCurrent = prometheus.NewDesc(
+			"fortigate_per_ip_shaper_current_bandwidth_bps",
+			"Current bandwidth of all addresses of the per-IP traffic shaper in bits/s",
+			[]string{"vdom", "name"}, nil,
+		)
+		mPerIPMaximum = prometheus.NewDesc(
+			"fortigate_per_ip_shaper_maximum_bandwidth_bps",
+			"Configured maximum bandwidth per address of the per-IP traffic shaper in bits/s",
+			[]string{"vdom", "name"}, nil,
+		)
+		mPerIPDropped = prometheus.NewDesc(
+			"fortigate_per_ip_shaper_dropped_bytes_total",
+			"Total number of bytes dropped by the per-IP traffic shaper",
+			[]string{"vdom", "name"}, nil,
+		)
+	)
+
+	var shapers []ShaperResponse
+	if err := c.Get("api/v2/monitor/firewall/shaper", "vdom=*", &shapers); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	var perIPShapers []PerIPShaperResponse
+	if err := c.Get("api/v2/monitor/firewall/per-ip-shaper", "vdom=*", &perIPShapers); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range shapers {
+		for _, s := range r.Results {
+			scale := shaperBandwidthScale(s.BandwidthUnit)
+			m = append(m, prometheus.MustNewConstMetric(mCurrent, prometheus.GaugeValue, s.CurrentBandwidth*scale, r.VDOM, s.Name))
+			m = append(m, prometheus.MustNewConstMetric(mGuaranteed, prometheus.GaugeValue, s.GuaranteedBandwidth*scale, r.VDOM, s.Name))
+			m = append(m, prometheus.MustNewConstMetric(mMaximum, prometheus.GaugeValue, s.MaximumBandwidth*scale, r.VDOM, s.Name))
+			m = append(m, prometheus.MustNewConstMetric(mDropped, prometheus.CounterValue, s.DroppedBytes, r.VDOM, s.Name))
+		}
+	}
+	for _, r := range perIPShapers {
+		for _, s := range r.Results {
+			scale := shaperBandwidthScale(s.BandwidthUnit)
+			m = append(m, prometheus.MustNewConstMetric(mPerIPCurrent, prometheus.GaugeValue, s.CurrentBandwidth*scale, r.VDOM, s.Name))
+			m = append(m, prometheus.MustNewConstMetric(mPerIPMaximum, prometheus.GaugeValue, s.MaxBandwidth*scale, r.VDOM, s.Name))
+			m = append(m, prometheus.MustNewConstMetric(mPerIPDropped, prometheus.CounterValue, s.DroppedBytes, r.VDOM, s.Name))
+		}
+	}
+
+	return m, true
+}
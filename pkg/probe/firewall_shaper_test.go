@@ -0,0 +1,68 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestFirewallShaper(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/firewall/shaper", "testdata/firewall-shaper.jsonnet")
+	c.prepare("api/v2/monitor/firewall/per-ip-shaper", "testdata/firewall-per-ip-shaper.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeFirewallShaper, c, r) {
+		t.Errorf("probeFirewallShaper() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_per_ip_shaper_current_bandwidth_bps Current bandwidth of all addresses of the per-IP traffic shaper in bits/s
+	# TYPE fortigate_per_ip_shaper_current_bandwidth_bps gauge
+	fortigate_per_ip_shaper_current_bandwidth_bps{name="guest-per-ip",vdom="guest"} 3.2e+06
+	# HELP fortigate_per_ip_shaper_dropped_bytes_total Total number of bytes dropped by the per-IP traffic shaper
+	# TYPE fortigate_per_ip_shaper_dropped_bytes_total counter
+	fortigate_per_ip_shaper_dropped_bytes_total{name="guest-per-ip",vdom="guest"} 4096
+	# HELP fortigate_per_ip_shaper_maximum_bandwidth_bps Configured maximum bandwidth per address of the per-IP traffic shaper in bits/s
+	# TYPE fortigate_per_ip_shaper_maximum_bandwidth_bps gauge
+	fortigate_per_ip_shaper_maximum_bandwidth_bps{name="guest-per-ip",vdom="guest"} 5e+06
+	# HELP fortigate_shaper_current_bandwidth_bps Current bandwidth of the traffic shaper in bits/s
+	# TYPE fortigate_shaper_current_bandwidth_bps gauge
+	fortigate_shaper_current_bandwidth_bps{name="guest-limit",vdom="guest"} 5e+08
+	fortigate_shaper_current_bandwidth_bps{name="high-priority",vdom="root"} 2.048e+06
+	fortigate_shaper_current_bandwidth_bps{name="voip",vdom="root"} 1.2e+07
+	# HELP fortigate_shaper_dropped_bytes_total Total number of bytes dropped by the traffic shaper
+	# TYPE fortigate_shaper_dropped_bytes_total counter
+	fortigate_shaper_dropped_bytes_total{name="guest-limit",vdom="guest"} 9.87654321e+08
+	fortigate_shaper_dropped_bytes_total{name="high-priority",vdom="root"} 0
+	fortigate_shaper_dropped_bytes_total{name="voip",vdom="root"} 12345
+	# HELP fortigate_shaper_guaranteed_bandwidth_bps Configured guaranteed bandwidth of the traffic shaper in bits/s
+	# TYPE fortigate_shaper_guaranteed_bandwidth_bps gauge
+	fortigate_shaper_guaranteed_bandwidth_bps{name="guest-limit",vdom="guest"} 0
+	fortigate_shaper_guaranteed_bandwidth_bps{name="high-priority",vdom="root"} 0
+	fortigate_shaper_guaranteed_bandwidth_bps{name="voip",vdom="root"} 1e+07
+	# HELP fortigate_shaper_maximum_bandwidth_bps Configured maximum bandwidth of the traffic shaper in bits/s
+	# TYPE fortigate_shaper_maximum_bandwidth_bps gauge
+	fortigate_shaper_maximum_bandwidth_bps{name="guest-limit",vdom="guest"} 1e+09
+	fortigate_shaper_maximum_bandwidth_bps{name="high-priority",vdom="root"} 1.048576e+09
+	fortigate_shaper_maximum_bandwidth_bps{name="voip",vdom="root"} 5e+07
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
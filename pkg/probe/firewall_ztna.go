@@ -0,0 +1,118 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type ProxyPolicyStats struct {
+	ID             int64   `json:"policyid"`
+	ActiveSessions float64 `json:"active_sessions"`
+	Bytes          float64 `json:"bytes"`
+	HitCount       float64 `json:"hit_count"`
+}
+
+type ProxyPolicyStatsResponse struct {
+	Results []ProxyPolicyStats `json:"results"`
+	VDOM    string             `json:"vdom"`
+}
+
+type ProxyPolicy struct {
+	ID          int64  `json:"policyid"`
+	Name        string `json:"name"`
+	Proxy       string `json:"proxy"`
+	AccessProxy []struct {
+		Name string `json:"name"`
+	} `json:"access-proxy"`
+}
+
+type ProxyPolicyResponse struct {
+	Results []ProxyPolicy `json:"results"`
+	VDOM    string        `json:"vdom"`
+}
+
+type proxyPolicyKey struct {
+	VDOM string
+	ID   int64
+}
+
+func probeFirewallZTNA(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mHitCount = prometheus.NewDesc(
+			"fortigate_ztna_rule_hit_count_total",
+			"Number of times a ZTNA rule has been hit",
+			[]string{"vdom", "name", "id", "access_proxy"}, nil,
+		)
+		mBytes = prometheus.NewDesc(
+			"fortigate_ztna_rule_bytes_total",
+			"Number of bytes that has passed through a ZTNA rule",
+			[]string{"vdom", "name", "id", "access_proxy"}, nil,
+		)
+		mActiveSessions = prometheus.NewDesc(
+			"fortigate_ztna_rule_active_sessions",
+			"Number of active sessions for a ZTNA rule",
+			[]string{"vdom", "name", "id", "access_proxy"}, nil,
+		)
+	)
+
+	var policies []ProxyPolicyResponse
+	if err := c.Get("api/v2/cmdb/firewall/proxy-policy", "vdom=*&format=policyid|name|proxy|access-proxy", &policies); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	var stats []ProxyPolicyStatsResponse
+	if err := c.Get("api/v2/monitor/firewall/proxy-policy", "vdom=*", &stats); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	// Only proxy policies of the access proxies are ZTNA rules
+	rules := map[proxyPolicyKey]ProxyPolicy{}
+	for _, r := range policies {
+		for _, p := range r.Results {
+			if p.Proxy == "access-proxy" {
+				rules[proxyPolicyKey{VDOM: r.VDOM, ID: p.ID}] = p
+			}
+		}
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range stats {
+		for _, s := range r.Results {
+			p, ok := rules[proxyPolicyKey{VDOM: r.VDOM, ID: s.ID}]
+			if !ok {
+				continue
+			}
+			proxies := []string{}
+			for _, ap := range p.AccessProxy {
+				proxies = append(proxies, ap.Name)
+			}
+			id := strconv.FormatInt(s.ID, 10)
+			accessProxy := strings.Join(proxies, ",")
+			m = append(m, prometheus.MustNewConstMetric(mHitCount, prometheus.CounterValue, s.HitCount, r.VDOM, p.Name, id, accessProxy))
+			m = append(m, prometheus.MustNewConstMetric(mBytes, prometheus.CounterValue, s.Bytes, r.VDOM, p.Name, id, accessProxy))
+			m = append(m, prometheus.MustNewConstMetric(mActiveSessions, prometheus.GaugeValue, s.ActiveSessions, r.VDOM, p.Name, id, accessProxy))
+		}
+	}
+
+	return m, true
+}
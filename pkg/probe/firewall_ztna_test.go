@@ -0,0 +1,51 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestFirewallZTNA(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/cmdb/firewall/proxy-policy", "testdata/firewall-proxy-policy-cmdb.jsonnet")
+	c.prepare("api/v2/monitor/firewall/proxy-policy", "testdata/firewall-proxy-policy.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeFirewallZTNA, c, r) {
+		t.Errorf("probeFirewallZTNA() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ztna_rule_active_sessions Number of active sessions for a ZTNA rule
+	# TYPE fortigate_ztna_rule_active_sessions gauge
+	fortigate_ztna_rule_active_sessions{access_proxy="ztna-gw",id="1",name="ztna-webapps",vdom="root"} 14
+	fortigate_ztna_rule_active_sessions{access_proxy="ztna-gw,ztna-gw2",id="2",name="ztna-ssh",vdom="root"} 2
+	# HELP fortigate_ztna_rule_bytes_total Number of bytes that has passed through a ZTNA rule
+	# TYPE fortigate_ztna_rule_bytes_total counter
+	fortigate_ztna_rule_bytes_total{access_proxy="ztna-gw",id="1",name="ztna-webapps",vdom="root"} 9.87654321e+08
+	fortigate_ztna_rule_bytes_total{access_proxy="ztna-gw,ztna-gw2",id="2",name="ztna-ssh",vdom="root"} 1.234567e+06
+	# HELP fortigate_ztna_rule_hit_count_total Number of times a ZTNA rule has been hit
+	# TYPE fortigate_ztna_rule_hit_count_total counter
+	fortigate_ztna_rule_hit_count_total{access_proxy="ztna-gw",id="1",name="ztna-webapps",vdom="root"} 5120
+	fortigate_ztna_rule_hit_count_total{access_proxy="ztna-gw,ztna-gw2",id="2",name="ztna-ssh",vdom="root"} 77
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
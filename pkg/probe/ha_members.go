@@ -0,0 +1,69 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+// haMemberCollector holds the metrics of probes run against a single HA
+// cluster member. It is registered with the "ha_member" and "ha_role" labels
+// added to every metric.
+type haMemberCollector struct {
+	serial  string
+	role    string
+	client  http.FortiHTTP
+	metrics []prometheus.Metric
+}
+
+func (p *haMemberCollector) Collect(c chan<- prometheus.Metric) {
+	for _, m := range p.metrics {
+		c <- m
+	}
+}
+
+func (p *haMemberCollector) Describe(_ chan<- *prometheus.Desc) {
+}
+
+func (p *haMemberCollector) labels() prometheus.Labels {
+	return prometheus.Labels{"ha_member": p.serial, "ha_role": p.role}
+}
+
+// fetchHAMembers returns one collector per cluster member, using the
+// ha-checksums endpoint as it lists every member with its serial and role.
+// The role follows is_root_master, the primary of the cluster (of virtual
+// cluster 1), and not is_manage_master which marks the unit handling the
+// management connection.
+func fetchHAMembers(c http.FortiHTTP) ([]*haMemberCollector, error) {
+	var res HAChecksum
+	if err := c.Get("api/v2/monitor/system/ha-checksums", "scope=global", &res); err != nil {
+		return nil, err
+	}
+
+	members := []*haMemberCollector{}
+	for _, r := range res.Results {
+		role := "secondary"
+		if r.IsRootMaster == 1 {
+			role = "primary"
+		}
+		members = append(members, &haMemberCollector{
+			serial: r.SerialNo,
+			role:   role,
+			client: http.NewHAMemberClient(c, r.SerialNo),
+		})
+	}
+	return members, nil
+}
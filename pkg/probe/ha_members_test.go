@@ -0,0 +1,56 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestHAMembers(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/ha-checksums", "testdata/ha-checksum-members.jsonnet")
+	c.prepare("api/v2/monitor/system/status?ha_serial=SERIAL111111111", "testdata/status.jsonnet")
+	c.prepare("api/v2/monitor/system/status?ha_serial=SERIAL222222222", "testdata/status.jsonnet")
+
+	members, err := fetchHAMembers(c)
+	if err != nil {
+		t.Fatalf("fetchHAMembers() returned error: %v", err)
+	}
+
+	pc := &Collector{members: members}
+	for _, member := range pc.members {
+		m, ok := probeSystemStatus(member.client, &TargetMetadata{VersionMajor: 7, VersionMinor: 4})
+		if !ok {
+			t.Errorf("probeSystemStatus() returned non-success for member %q", member.serial)
+		}
+		member.metrics = m
+	}
+	r := prometheus.NewPedanticRegistry()
+	pc.RegisterHAMembers(r)
+
+	em := `
+	# HELP fortigate_version_info System version and build information
+	# TYPE fortigate_version_info gauge
+	fortigate_version_info{build="1112",ha_member="SERIAL111111111",ha_role="primary",serial="FGVMEVZFNTS3OAC8",version="v6.2.4"} 1
+	fortigate_version_info{build="1112",ha_member="SERIAL222222222",ha_role="secondary",serial="FGVMEVZFNTS3OAC8",version="v6.2.4"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -14,6 +14,7 @@
 package probe
 
 import (
+	"encoding/json"
 	"log"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +22,18 @@ import (
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
+// licensedStatus lists the statuses of an entitled service. FortiCare reports
+// an active contract as registered, FortiCloud a logged in account as
+// cloud_logged_in and some services are included as free_license.
+var licensedStatus = map[string]bool{
+	"licensed":        true,
+	"registered":      true,
+	"cloud_logged_in": true,
+	"free_license":    true,
+	"vm_valid":        true,
+	"vm_eval":         true,
+}
+
 func probeLicenseStatus(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
 	var (
 		vdomUsed = prometheus.NewDesc(
@@ -33,19 +46,61 @@ func probeLicenseStatus(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metri
 			"The total amount of VDOM licenses available",
 			[]string{}, nil,
 		)
+		serviceInfo = prometheus.NewDesc(
+			"fortigate_license_service_info",
+			"Entitlement status of FortiGuard and FortiCare services",
+			[]string{"service", "type", "status"}, nil,
+		)
+		serviceLicensed = prometheus.NewDesc(
+			"fortigate_license_service_licensed",
+			"Whether the service is licensed (0 - No, 1 - Yes)",
+			[]string{"service"}, nil,
+		)
+		serviceExpiry = prometheus.NewDesc(
+			"fortigate_license_service_expiry_timestamp_seconds",
+			"Expiry time of the service license in seconds from epoch",
+			[]string{"service"}, nil,
+		)
+		signatureInfo = prometheus.NewDesc(
+			"fortigate_license_signature_info",
+			"Version of the signature database or engine downloaded for a service",
+			[]string{"service", "component", "version"}, nil,
+		)
+		signatureUpdate = prometheus.NewDesc(
+			"fortigate_license_signature_last_update_timestamp_seconds",
+			"Time of the last update of the signature database or engine in seconds from epoch",
+			[]string{"service", "component"}, nil,
+		)
 	)
 
-	type LicenseStatus struct {
-		VDOM struct {
-			Type       string  `json:"type"`
-			CanUpgrade bool    `json:"can_upgrade"`
-			Used       float64 `json:"used"`
-			Max        float64 `json:"max"`
-		} `json:"vdom"`
+	type LicenseComponent struct {
+		Version    string  `json:"version"`
+		LastUpdate float64 `json:"last_update"`
+	}
+
+	type LicenseSupport struct {
+		Status  string  `json:"status"`
+		Expires float64 `json:"expires"`
 	}
 
+	type LicenseService struct {
+		Type    string  `json:"type"`
+		Status  string  `json:"status"`
+		Expires float64 `json:"expires"`
+		LicenseComponent
+		Engine              *LicenseComponent         `json:"engine"`
+		ConfigurationScript *LicenseComponent         `json:"configuration_script"`
+		Support             map[string]LicenseSupport `json:"support"`
+		// Only set for platform entries like vdom
+		Used float64 `json:"used"`
+		Max  float64 `json:"max"`
+	}
+
+	// The services differ between versions and models, so they are decoded
+	// one by one into a map keyed by name, including the platform entries
+	// like vdom. An entry of unexpected shape only skips that entry.
 	type LicenseResponse struct {
-		Results LicenseStatus `json:"results"`
+		Results map[string]json.RawMessage `json:"results"`
 	}
 	var r LicenseResponse
 
@@ -54,9 +109,52 @@ func probeLicenseStatus(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metri
 		return nil, false
 	}
 
+	services := map[string]LicenseService{}
+	for name, raw := range r.Results {
+		var s LicenseService
+		if err := json.Unmarshal(raw, &s); err != nil {
+			log.Printf("Warning: Skipping license entry %q: %v", name, err)
+			continue
+		}
+		services[name] = s
+	}
+
 	m := []prometheus.Metric{
-		prometheus.MustNewConstMetric(vdomUsed, prometheus.GaugeValue, float64(r.Results.VDOM.Used)),
-		prometheus.MustNewConstMetric(vdomMax, prometheus.GaugeValue, float64(r.Results.VDOM.Max)),
+		prometheus.MustNewConstMetric(vdomUsed, prometheus.GaugeValue, services["vdom"].Used),
+		prometheus.MustNewConstMetric(vdomMax, prometheus.GaugeValue, services["vdom"].Max),
+	}
+
+	addService := func(name, typ, status string, expires float64) {
+		m = append(m, prometheus.MustNewConstMetric(serviceInfo, prometheus.GaugeValue, 1, name, typ, status))
+		licensed := 0.0
+		if licensedStatus[status] {
+			licensed = 1.0
+		}
+		m = append(m, prometheus.MustNewConstMetric(serviceLicensed, prometheus.GaugeValue, licensed, name))
+		if expires > 0 {
+			m = append(m, prometheus.MustNewConstMetric(serviceExpiry, prometheus.GaugeValue, expires, name))
+		}
+	}
+	addComponent := func(service, component string, lc *LicenseComponent) {
+		if lc == nil || lc.Version == "" {
+			return
+		}
+		m = append(m, prometheus.MustNewConstMetric(signatureInfo, prometheus.GaugeValue, 1, service, component, lc.Version))
+		m = append(m, prometheus.MustNewConstMetric(signatureUpdate, prometheus.GaugeValue, lc.LastUpdate, service, component))
+	}
+
+	for name, s := range services {
+		// Entries like vdom or fortiguard describe the platform, not a licensed service
+		if s.Status == "" {
+			continue
+		}
+		addService(name, s.Type, s.Status, s.Expires)
+		for kind, support := range s.Support {
+			addService(name+"_"+kind, s.Type, support.Status, support.Expires)
+		}
+		addComponent(name, "database", &s.LicenseComponent)
+		addComponent(name, "engine", s.Engine)
+		addComponent(name, "configuration_script", s.ConfigurationScript)
 	}
 
 	return m, true
@@ -36,6 +36,117 @@ func TestLicenseStatus(t *testing.T) {
         # HELP fortigate_license_vdom_max The total amount of VDOM licenses available
         # TYPE fortigate_license_vdom_max gauge
         fortigate_license_vdom_max 125
+        # HELP fortigate_license_service_info Entitlement status of FortiGuard and FortiCare services
+        # TYPE fortigate_license_service_info gauge
+        fortigate_license_service_info{service="sms",status="no_license",type="other"} 1
+        # HELP fortigate_license_service_licensed Whether the service is licensed (0 - No, 1 - Yes)
+        # TYPE fortigate_license_service_licensed gauge
+        fortigate_license_service_licensed{service="sms"} 0
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestLicenseStatusServices(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/license/status/select", "testdata/license-61f-full.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeLicenseStatus, c, r) {
+		t.Errorf("probeLicenseStatus() returned non-success")
+	}
+
+	em := `
+        # HELP fortigate_license_service_expiry_timestamp_seconds Expiry time of the service license in seconds from epoch
+        # TYPE fortigate_license_service_expiry_timestamp_seconds gauge
+        fortigate_license_service_expiry_timestamp_seconds{service="antispam"} 1.5898464e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="appctrl"} 1.6216416e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="blacklisted_certificates"} 1.5898464e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="device_os_id"} 1.6216416e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="forticare_enhanced"} 1.6216416e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="forticare_hardware"} 1.6216416e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="web_filtering"} 1.5898464e+09
+        # HELP fortigate_license_signature_info Version of the signature database or engine downloaded for a service
+        # TYPE fortigate_license_signature_info gauge
+        fortigate_license_signature_info{component="configuration_script",service="ips",version="1.00009"} 1
+        fortigate_license_signature_info{component="database",service="antivirus",version="1.00000"} 1
+        fortigate_license_signature_info{component="database",service="appctrl",version="15.00848"} 1
+        fortigate_license_signature_info{component="database",service="blacklisted_certificates",version="0.00000"} 1
+        fortigate_license_signature_info{component="database",service="botnet_domain",version="0.00000"} 1
+        fortigate_license_signature_info{component="database",service="botnet_ip",version="1.00000"} 1
+        fortigate_license_signature_info{component="database",service="device_os_id",version="1.00100"} 1
+        fortigate_license_signature_info{component="database",service="industrial_db",version="6.00741"} 1
+        fortigate_license_signature_info{component="database",service="internet_service_db",version="7.00715"} 1
+        fortigate_license_signature_info{component="database",service="ips",version="6.00741"} 1
+        fortigate_license_signature_info{component="database",service="malicious_urls",version="2.00654"} 1
+        fortigate_license_signature_info{component="database",service="mobile_malware",version="0.00000"} 1
+        fortigate_license_signature_info{component="database",service="security_rating",version="2.00036"} 1
+        fortigate_license_signature_info{component="engine",service="antivirus",version="6.00144"} 1
+        fortigate_license_signature_info{component="engine",service="ips",version="5.00209"} 1
+        # HELP fortigate_license_service_licensed Whether the service is licensed (0 - No, 1 - Yes)
+        # TYPE fortigate_license_service_licensed gauge
+        fortigate_license_service_licensed{service="antispam"} 0
+        fortigate_license_service_licensed{service="antivirus"} 0
+        fortigate_license_service_licensed{service="appctrl"} 1
+        fortigate_license_service_licensed{service="blacklisted_certificates"} 0
+        fortigate_license_service_licensed{service="botnet_domain"} 0
+        fortigate_license_service_licensed{service="botnet_ip"} 0
+        fortigate_license_service_licensed{service="device_os_id"} 1
+        fortigate_license_service_licensed{service="fortianalyzer_cloud"} 0
+        fortigate_license_service_licensed{service="forticare"} 1
+        fortigate_license_service_licensed{service="forticare_enhanced"} 1
+        fortigate_license_service_licensed{service="forticare_hardware"} 1
+        fortigate_license_service_licensed{service="forticloud"} 1
+        fortigate_license_service_licensed{service="forticloud_logging"} 1
+        fortigate_license_service_licensed{service="forticloud_sandbox"} 1
+        fortigate_license_service_licensed{service="fortimanager_cloud"} 0
+        fortigate_license_service_licensed{service="industrial_db"} 0
+        fortigate_license_service_licensed{service="internet_service_db"} 1
+        fortigate_license_service_licensed{service="ips"} 0
+        fortigate_license_service_licensed{service="malicious_urls"} 0
+        fortigate_license_service_licensed{service="mobile_malware"} 0
+        fortigate_license_service_licensed{service="outbreak_prevention"} 0
+        fortigate_license_service_licensed{service="security_rating"} 0
+        fortigate_license_service_licensed{service="sms"} 0
+        fortigate_license_service_licensed{service="web_filtering"} 0
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em),
+		"fortigate_license_service_expiry_timestamp_seconds", "fortigate_license_signature_info", "fortigate_license_service_licensed"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestLicenseStatusForticare(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/license/status/select", "testdata/license-forticare.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeLicenseStatus, c, r) {
+		t.Errorf("probeLicenseStatus() returned non-success")
+	}
+
+	// ai_malware_detection has an unexpected expires value and is skipped,
+	// the other entries are still reported
+	em := `
+        # HELP fortigate_license_vdom_usage The amount of VDOM licenses currently used
+        # TYPE fortigate_license_vdom_usage gauge
+        fortigate_license_vdom_usage 3
+        # HELP fortigate_license_vdom_max The total amount of VDOM licenses available
+        # TYPE fortigate_license_vdom_max gauge
+        fortigate_license_vdom_max 10
+        # HELP fortigate_license_service_expiry_timestamp_seconds Expiry time of the service license in seconds from epoch
+        # TYPE fortigate_license_service_expiry_timestamp_seconds gauge
+        fortigate_license_service_expiry_timestamp_seconds{service="forticare_enhanced"} 1.7277408e+09
+        fortigate_license_service_expiry_timestamp_seconds{service="forticare_hardware"} 1.7908128e+09
+        # HELP fortigate_license_service_info Entitlement status of FortiGuard and FortiCare services
+        # TYPE fortigate_license_service_info gauge
+        fortigate_license_service_info{service="forticare",status="registered",type="cloud_service_status"} 1
+        fortigate_license_service_info{service="forticare_enhanced",status="expired",type="cloud_service_status"} 1
+        fortigate_license_service_info{service="forticare_hardware",status="licensed",type="cloud_service_status"} 1
+        # HELP fortigate_license_service_licensed Whether the service is licensed (0 - No, 1 - Yes)
+        # TYPE fortigate_license_service_licensed gauge
+        fortigate_license_service_licensed{service="forticare"} 1
+        fortigate_license_service_licensed{service="forticare_enhanced"} 0
+        fortigate_license_service_licensed{service="forticare_hardware"} 1
 	`
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
 		t.Fatalf("metric compare: err %v", err)
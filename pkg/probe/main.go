@@ -66,6 +66,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("probe: %v", err), http.StatusBadRequest)
 		return
 	}
+	pc.RegisterHAMembers(registry)
 	duration := time.Since(start).Seconds()
 	probeDurationGauge.Set(duration)
 	if success {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
@@ -229,18 +230,13 @@ func probeManagedSwitch(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metri
 		PortStats      map[string]PortStat `json:"port_stats"`
 	}
 
-	type managedResponse []struct {
-		Results []Results `json:"results"`
-	}
-
-	// Consider implementing pagination to remove this limit of 1000 entries
-	var response managedResponse
-	if err := c.Get("api/v2/monitor/switch-controller/managed-switch", "vdom=*&start=0&poe=true&port_stats=true&transceiver=true&count=1000", &response); err != nil {
+	response, truncated, err := http.GetAll[Results](c, "api/v2/monitor/switch-controller/managed-switch", "vdom=*&poe=true&port_stats=true&transceiver=true", config.GetConfig().MaxListEntries)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
 
-	var m []prometheus.Metric
+	m := []prometheus.Metric{probeTruncated("Switch/ManagedSwitch", truncated)}
 	for _, rs := range response {
 		for _, result := range rs.Results {
 			m = append(m, prometheus.MustNewConstMetric(managedSwitchInfo, prometheus.CounterValue, 1, result.VDOM, result.Name, result.OSVersion, result.Serial, result.State, result.Status))
@@ -19,9 +19,15 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 )
 
 func TestProbeManagedSwitch(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/switch-controller/managed-switch", "testdata/managed-switch.jsonnet")
 	r := prometheus.NewPedanticRegistry()
@@ -838,6 +844,9 @@ func TestProbeManagedSwitch(t *testing.T) {
 		fortigate_managed_switch_under_size_total{port="port7",switch_name="FOO-SW-01",vdom="root"} 0
 		fortigate_managed_switch_under_size_total{port="port8",switch_name="FOO-SW-01",vdom="root"} 0
 		fortigate_managed_switch_under_size_total{port="port9",switch_name="FOO-SW-01",vdom="root"} 0
+		# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+		# TYPE fortigate_probe_truncated gauge
+		fortigate_probe_truncated{probe="Switch/ManagedSwitch"} 0
 		`
 
 	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
@@ -0,0 +1,104 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type NeighborEntry struct {
+	Interface string `json:"interface"`
+}
+
+type interfaceCount struct {
+	VDOM      string
+	Interface string
+}
+
+// neighborCounter counts the ARP or IPv6 neighbor entries per interface
+// while they are decoded.
+type neighborCounter struct {
+	counts map[interfaceCount]int
+	// counts of the VDOM currently decoded, keyed by interface
+	cur map[string]int
+}
+
+func newNeighborCounter() *neighborCounter {
+	return &neighborCounter{
+		counts: make(map[interfaceCount]int),
+		cur:    make(map[string]int),
+	}
+}
+
+func (n *neighborCounter) Entry(e NeighborEntry) {
+	n.cur[e.Interface]++
+}
+
+func (n *neighborCounter) EndVDOM(vdom string) {
+	for iface, count := range n.cur {
+		n.counts[interfaceCount{VDOM: vdom, Interface: iface}] += count
+	}
+	clear(n.cur)
+}
+
+func probeNetworkARP(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mARP = prometheus.NewDesc(
+			"fortigate_arp_entries",
+			"Number of entries in the ARP table",
+			[]string{"vdom", "interface"}, nil,
+		)
+		mNeighbor = prometheus.NewDesc(
+			"fortigate_ipv6_neighbor_entries",
+			"Number of entries in the IPv6 neighbor cache",
+			[]string{"vdom", "interface"}, nil,
+		)
+	)
+
+	maxEntries := config.GetConfig().MaxListEntries
+
+	arp := newNeighborCounter()
+	arpTruncated, err := http.VisitAll[NeighborEntry](c, "api/v2/monitor/network/arp", "vdom=*", maxEntries, arp)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	neighbors := newNeighborCounter()
+	neighborsTruncated, err := http.VisitAll[NeighborEntry](c, "api/v2/monitor/network/ipv6-neighbor-cache", "vdom=*", maxEntries, neighbors)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	truncated := arpTruncated || neighborsTruncated
+	if truncated {
+		log.Printf("Warning: Received more ARP or IPv6 neighbor entries than maximum (%d) allowed, counts are incomplete", maxEntries)
+	}
+
+	m := []prometheus.Metric{probeTruncated("Network/ARP", truncated)}
+	for k, count := range arp.counts {
+		m = append(m, prometheus.MustNewConstMetric(mARP, prometheus.GaugeValue, float64(count), k.VDOM, k.Interface))
+	}
+	for k, count := range neighbors.counts {
+		m = append(m, prometheus.MustNewConstMetric(mNeighbor, prometheus.GaugeValue, float64(count), k.VDOM, k.Interface))
+	}
+
+	return m, true
+}
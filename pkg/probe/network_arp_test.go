@@ -0,0 +1,56 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestNetworkARP(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/network/arp", "testdata/network-arp.jsonnet")
+	c.prepare("api/v2/monitor/network/ipv6-neighbor-cache", "testdata/network-ipv6-neighbor-cache.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeNetworkARP, c, r) {
+		t.Errorf("probeNetworkARP() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_arp_entries Number of entries in the ARP table
+	# TYPE fortigate_arp_entries gauge
+	fortigate_arp_entries{interface="guest",vdom="guest"} 2
+	fortigate_arp_entries{interface="port1",vdom="root"} 1
+	fortigate_arp_entries{interface="port2",vdom="root"} 5
+	# HELP fortigate_ipv6_neighbor_entries Number of entries in the IPv6 neighbor cache
+	# TYPE fortigate_ipv6_neighbor_entries gauge
+	fortigate_ipv6_neighbor_entries{interface="port1",vdom="root"} 1
+	fortigate_ipv6_neighbor_entries{interface="port2",vdom="root"} 2
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Network/ARP"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type LLDPNeighbor struct {
+	Port       string `json:"port"`
+	ChassisID  string `json:"chassis_id"`
+	PortID     string `json:"port_id"`
+	SystemName string `json:"system_name"`
+}
+
+type LLDPNeighborResponse struct {
+	Results []LLDPNeighbor `json:"results"`
+	VDOM    string         `json:"vdom"`
+}
+
+type LLDPPort struct {
+	Port string `json:"port"`
+}
+
+type LLDPPortResponse struct {
+	Results []LLDPPort `json:"results"`
+	VDOM    string     `json:"vdom"`
+}
+
+func probeNetworkLLDP(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mInfo = prometheus.NewDesc(
+			"fortigate_lldp_neighbor_info",
+			"LLDP neighbor seen on a local port",
+			[]string{"vdom", "port", "chassis_id", "system_name", "port_id"}, nil,
+		)
+		mNeighbors = prometheus.NewDesc(
+			"fortigate_lldp_neighbors",
+			"Number of LLDP neighbors seen on a local port",
+			[]string{"vdom", "port"}, nil,
+		)
+	)
+
+	var ports []LLDPPortResponse
+	if err := c.Get("api/v2/monitor/network/lldp/ports", "vdom=*", &ports); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	var neighbors []LLDPNeighborResponse
+	if err := c.Get("api/v2/monitor/network/lldp/neighbors", "vdom=*", &neighbors); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	counts := map[interfaceCount]int{}
+	for _, r := range ports {
+		for _, p := range r.Results {
+			counts[interfaceCount{VDOM: r.VDOM, Interface: p.Port}] = 0
+		}
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range neighbors {
+		for _, n := range r.Results {
+			counts[interfaceCount{VDOM: r.VDOM, Interface: n.Port}]++
+			m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, r.VDOM, n.Port, n.ChassisID, n.SystemName, n.PortID))
+		}
+	}
+	for k, count := range counts {
+		m = append(m, prometheus.MustNewConstMetric(mNeighbors, prometheus.GaugeValue, float64(count), k.VDOM, k.Interface))
+	}
+
+	return m, true
+}
@@ -0,0 +1,49 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNetworkLLDP(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/network/lldp/ports", "testdata/network-lldp-ports.jsonnet")
+	c.prepare("api/v2/monitor/network/lldp/neighbors", "testdata/network-lldp-neighbors.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeNetworkLLDP, c, r) {
+		t.Errorf("probeNetworkLLDP() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_lldp_neighbor_info LLDP neighbor seen on a local port
+	# TYPE fortigate_lldp_neighbor_info gauge
+	fortigate_lldp_neighbor_info{chassis_id="00:1b:21:00:00:09",port="port2",port_id="00:1b:21:00:00:09",system_name="hypervisor01",vdom="root"} 1
+	fortigate_lldp_neighbor_info{chassis_id="70:4c:a5:00:00:01",port="port1",port_id="Gi1/0/24",system_name="core-sw1",vdom="root"} 1
+	fortigate_lldp_neighbor_info{chassis_id="70:4c:a5:00:00:02",port="port2",port_id="port5",system_name="access-sw1",vdom="root"} 1
+	# HELP fortigate_lldp_neighbors Number of LLDP neighbors seen on a local port
+	# TYPE fortigate_lldp_neighbors gauge
+	fortigate_lldp_neighbors{port="port1",vdom="root"} 1
+	fortigate_lldp_neighbors{port="port2",vdom="root"} 2
+	fortigate_lldp_neighbors{port="port3",vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -47,6 +48,7 @@ import (
 
 type Collector struct {
 	metrics []prometheus.Metric
+	members []*haMemberCollector
 }
 
 type TargetMetadata struct {
@@ -81,8 +83,13 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 		// Add the target and its apikey to the savedConfig and use, if exists, a target entry as a template for include/exclude
 		// This will only happened the "first" time
 		savedConfig.AuthKeys[config.Target(target["target"])] = config.TargetAuth{
-			Token:  config.Token(target["token"]),
-			Probes: savedConfig.AuthKeys[config.Target(target["profile"])].Probes,
+			Token:    config.Token(target["token"]),
+			Probes:   savedConfig.AuthKeys[config.Target(target["profile"])].Probes,
+			HA:       savedConfig.AuthKeys[config.Target(target["profile"])].HA,
+			CMDB:     savedConfig.AuthKeys[config.Target(target["profile"])].CMDB,
+			Custom:   savedConfig.AuthKeys[config.Target(target["profile"])].Custom,
+			Routes:   savedConfig.AuthKeys[config.Target(target["profile"])].Routes,
+			Firmware: savedConfig.AuthKeys[config.Target(target["profile"])].Firmware,
 		}
 	}
 
@@ -123,10 +130,19 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 
 	includedProbes := savedConfig.AuthKeys[config.Target(u.String())].Probes.Include
 	excludedProbes := savedConfig.AuthKeys[config.Target(u.String())].Probes.Exclude
+	haProbes := savedConfig.AuthKeys[config.Target(u.String())].HA.Probes
 
-	// TODO: Make parallel
 	success := true
-	for _, aProbe := range []probeDetailedFunc{
+	if len(haProbes) != 0 {
+		p.members, err = fetchHAMembers(c)
+		if err != nil {
+			// Fall back to only probing the unit answering on the target address
+			log.Printf("Error: Failed to list HA members: %v", err)
+			success = false
+		}
+	}
+
+	probes := []probeDetailedFunc{
 		// Always keep probeSystemTime on top of the list to have the probe processed first.
 		// Therefore time returned is more accurate when integrated in Prometheus because
 		// timestamp for the metrics probe, in Prometheus, is obtained from the query time, not the reply time.
@@ -136,22 +152,39 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 		{"BGP/NeighborPaths/IPv6", probeBGPNeighborPathsIPv6},
 		{"BGP/Neighbors/IPv4", probeBGPNeighborsIPv4},
 		{"BGP/Neighbors/IPv6", probeBGPNeighborsIPv6},
+		{"Extender/Status", probeExtenderStatus},
 		{"Firewall/LoadBalance", probeFirewallLoadBalance},
 		{"Firewall/Policies", probeFirewallPolicies},
 		{"Firewall/IPPool", probeFirewallIPPool},
+		{"Firewall/Sessions", probeFirewallSessions},
+		{"Firewall/Shaper", probeFirewallShaper},
+		{"Firewall/ZTNA", probeFirewallZTNA},
 		{"License/Status", probeLicenseStatus},
 		{"Log/Fortianalyzer/Status", probeLogAnalyzer},
 		{"Log/Fortianalyzer/Queue", probeLogAnalyzerQueue},
 		{"Log/DiskUsage", probeLogCurrentDiskUsage},
+		{"Network/ARP", probeNetworkARP},
 		{"Network/Dns/Latency", probeNetworkDNSLatency},
+		{"Network/LLDP", probeNetworkLLDP},
+		{"Security/Antivirus", probeSecurityAntivirus},
+		{"Security/AppControl", newSecurityAppControlProbe(securityCategorySet(u.String(), "Security/AppControl"))},
+		{"Security/IPS", probeSecurityIPS},
+		{"Security/WebFilter", newSecurityWebFilterProbe(securityCategorySet(u.String(), "Security/WebFilter"))},
+		{"System/Admins", probeSystemAdmins},
+		{"System/Admins/Info", probeSystemAdminsInfo},
 		{"System/AvailableCertificates", probeSystemAvailableCertificates},
 		{"System/Central-Management/Status", probeSystemCentralManagementStatus},
+		{"System/ConfigRevision", probeSystemConfigRevision},
+		{"System/DHCP", probeSystemDHCP},
+		{"System/EMSConnector", probeSystemEMSConnector},
+		{"System/Firmware", newSystemFirmwareProbe(savedConfig.AuthKeys[config.Target(u.String())].Firmware.MinimumVersion)},
 		{"System/Fortimanager/Status", probeSystemFortimanagerStatus},
 		{"System/HAStatistics", probeSystemHAStatistics},
 		{"System/Interface", probeSystemInterface},
 		{"System/Interface/Transceivers", probeSystemInterfaceTransceivers},
 		{"System/LinkMonitor", probeSystemLinkMonitor},
 		{"System/Performance/Status", probeSystemPerformanceStatus},
+		{"System/NPU", probeSystemNPU},
 		{"System/Ntp/Status", probeSystemNtpStatus},
 		{"System/Resource/Usage", probeSystemResourceUsage},
 		{"System/Resource/Usage/VDOM", probeSystemResourceUsagePerVdom},
@@ -160,18 +193,34 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 		{"System/Status", probeSystemStatus},
 		{"System/VDOMResource", probeSystemVdomResource},
 		{"System/HAChecksum", probeSystemHAChecksum},
+		{"User/Firewall", probeUserFirewall},
 		{"User/Fsso", probeUserFsso},
 		{"VPN/IPSec", probeVPNIPSec},
 		{"VPN/Ssl/Connections", probeVPNSsl},
 		{"VPN/Ssl/Stats", probeVPNSslStats},
 		{"VirtualWAN/HealthCheck", probeVirtualWANHealthCheck},
+		{"VirtualWAN/Rules", probeVirtualWANRules},
 		{"WebUI/State", probeWebUIState},
 		{"Wifi/APStatus", probeWifiAPStatus},
 		{"Wifi/Clients", probeWifiClients},
 		{"Wifi/ManagedAP", probeWifiManagedAP},
+		{"Wifi/RogueAP", probeWifiRogueAP},
+		{"Wifi/RogueAP/Info", probeWifiRogueAPInfo},
+		{"Wifi/SSID", probeWifiSSID},
 		{"Switch/ManagedSwitch", probeManagedSwitch},
+		{"Switch/Topology", probeSwitchTopology},
 		{"OSPF/Neighbors", probeOSPFNeighbors},
-	} {
+		{"Router/Routes", newRouterRoutesProbe(savedConfig.AuthKeys[config.Target(u.String())].Routes.Prefixes)},
+	}
+	for _, cp := range savedConfig.AuthKeys[config.Target(u.String())].CMDB {
+		probes = append(probes, probeDetailedFunc{"CMDB/" + cp.Name, newCMDBProbe(cp)})
+	}
+	for _, cp := range savedConfig.AuthKeys[config.Target(u.String())].Custom {
+		probes = append(probes, probeDetailedFunc{"Custom/" + cp.Name, newCustomProbe(cp)})
+	}
+
+	// TODO: Make parallel
+	for _, aProbe := range probes {
 		wanted := false
 
 		if len(includedProbes) == 0 {
@@ -198,6 +247,27 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 			continue
 		}
 
+		perMember := false
+		if len(p.members) != 0 {
+			for _, haProbe := range haProbes {
+				if strings.HasPrefix(aProbe.name, haProbe) {
+					perMember = true
+					break
+				}
+			}
+		}
+
+		if perMember {
+			for _, member := range p.members {
+				m, ok := aProbe.function(member.client, meta)
+				if !ok {
+					success = false
+				}
+				member.metrics = append(member.metrics, m...)
+			}
+			continue
+		}
+
 		m, ok := aProbe.function(c, meta)
 		if !ok {
 			success = false
@@ -208,6 +278,16 @@ func (p *Collector) Probe(ctx context.Context, target map[string]string, hc *htt
 	return success, nil
 }
 
+// RegisterHAMembers registers the metrics of probes run per HA cluster member,
+// labelled with the serial and role of the member they were collected from.
+func (p *Collector) RegisterHAMembers(r prometheus.Registerer) {
+	for _, member := range p.members {
+		if err := prometheus.WrapRegistererWith(member.labels(), r).Register(member); err != nil {
+			log.Printf("Error: Failed to register metrics of HA member %q: %v", member.serial, err)
+		}
+	}
+}
+
 func (p *Collector) Collect(c chan<- prometheus.Metric) {
 	// Collect result of new probe functions
 	for _, m := range p.metrics {
@@ -217,3 +297,21 @@ func (p *Collector) Collect(c chan<- prometheus.Metric) {
 
 func (p *Collector) Describe(_ chan<- *prometheus.Desc) {
 }
+
+// timeNow is replaced in tests to get stable results for time dependent metrics
+var timeNow = time.Now
+
+// probeTruncated returns a metric telling whether the named probe hit the
+// configured cap of list entries, in which case its metrics are incomplete.
+func probeTruncated(name string, truncated bool) prometheus.Metric {
+	desc := prometheus.NewDesc(
+		"fortigate_probe_truncated",
+		"Whether the probe received more list entries than allowed and only reports a part of them",
+		[]string{"probe"}, nil,
+	)
+	v := 0.0
+	if truncated {
+		v = 1.0
+	}
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, name)
+}
@@ -31,19 +31,28 @@
 package probe
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	nethttp "net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"sync"
+	"testing"
 
 	"github.com/google/go-jsonnet"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
 type preparedResp struct {
-	d []byte
-	q url.Values
+	d   []byte
+	q   url.Values
+	err error
 }
 
 type fakeClient struct {
@@ -82,12 +91,20 @@ alt:
 				continue alt
 			}
 		}
+		if r.err != nil {
+			return r.err
+		}
 		return json.Unmarshal(r.d, obj)
 	}
 	log.Fatalf("No prepared response matched URL %q, query %q", path, query)
 	return nil
 }
 
+// prepareError makes requests of path fail with err
+func (c *fakeClient) prepareError(path string, err error) {
+	c.data[path] = append(c.data[path], preparedResp{err: err})
+}
+
 type Registry interface {
 	MustRegister(...prometheus.Collector)
 }
@@ -126,3 +143,83 @@ func testProbeWithMetadata(pf probeFunc, c http.FortiHTTP, meta *TargetMetadata,
 func newFakeClient() *fakeClient {
 	return &fakeClient{data: map[string][]preparedResp{}}
 }
+
+// newScaledClient returns a client talking to a local HTTPS server which
+// serves the jsonnet fixture jfile with the results of every VDOM repeated
+// scale times. Pages requested using start/count are served accordingly,
+// reporting the total number of entries of every VDOM.
+// It is meant for benchmarks, which should measure the real client.
+func newScaledClient(b *testing.B, jfile string, scale int) http.FortiHTTP {
+	vm := jsonnet.MakeVM()
+	snippet := fmt.Sprintf(`[r + {results: std.flattenArrays(std.makeArray(%d, function(i) r.results))} for r in import %q]`, scale, jfile)
+	output, err := vm.EvaluateAnonymousSnippet("scaled.jsonnet", snippet)
+	if err != nil {
+		b.Fatalf("Failed to evaluate jsonnet %q: %v", jfile, err)
+	}
+	var responses []map[string]any
+	if err := json.Unmarshal([]byte(output), &responses); err != nil {
+		b.Fatalf("Failed to parse jsonnet %q: %v", jfile, err)
+	}
+
+	// Render each page once, the server should not show up in the benchmark
+	var mu sync.Mutex
+	pages := map[string][]byte{}
+	ts := httptest.NewTLSServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		page, ok := pages[r.URL.RawQuery]
+		if !ok {
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			count, err := strconv.Atoi(r.URL.Query().Get("count"))
+			if err != nil {
+				count = -1
+			}
+			paged := []map[string]any{}
+			for _, resp := range responses {
+				p := map[string]any{}
+				for k, v := range resp {
+					p[k] = v
+				}
+				results, _ := resp["results"].([]any)
+				p["total"] = len(results)
+				results = results[min(start, len(results)):]
+				if count >= 0 {
+					results = results[:min(count, len(results))]
+				}
+				p["results"] = results
+				paged = append(paged, p)
+			}
+			page, _ = json.Marshal(paged)
+			pages[r.URL.RawQuery] = page
+		}
+		_, _ = w.Write(page)
+	}))
+	b.Cleanup(ts.Close)
+
+	tgt := url.URL{Scheme: "https", Host: ts.Listener.Addr().String()}
+	cfg := config.GetConfig()
+	cfg.AuthKeys = config.AuthKeys{config.Target(tgt.String()): {Token: "benchmark"}}
+	c, err := http.NewFortiClient(context.Background(), tgt, ts.Client(), cfg)
+	if err != nil {
+		b.Fatalf("NewFortiClient() failed: %v", err)
+	}
+	return c
+}
+
+// benchmarkProbe runs pf once to warm up the server and then b.N times,
+// reporting the allocations of the probe including decoding the responses.
+func benchmarkProbe(b *testing.B, pf probeFunc, c http.FortiHTTP) {
+	meta := &TargetMetadata{
+		VersionMajor: 7,
+		VersionMinor: 4,
+	}
+	if _, ok := pf(c, meta); !ok {
+		b.Fatalf("probe returned non-success")
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, ok := pf(c, meta); !ok {
+			b.Fatalf("probe returned non-success")
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type Route struct {
+	Type      string `json:"type"`
+	IPMask    string `json:"ip_mask"`
+	Interface string `json:"interface"`
+}
+
+type RouterStatistics struct {
+	Results struct {
+		TotalLinesIPv4 int `json:"total_lines_ipv4"`
+		TotalLinesIPv6 int `json:"total_lines_ipv6"`
+	} `json:"results"`
+	VDOM string `json:"vdom"`
+}
+
+type routeCount struct {
+	VDOM      string
+	IPVersion string
+	Protocol  string
+	Interface string
+}
+
+type routePresence struct {
+	VDOM   string
+	Prefix string
+}
+
+// routeCounter counts the routes while they are decoded and notes which of
+// the watched prefixes have been seen.
+type routeCounter struct {
+	ipVersion string
+	watched   map[netip.Prefix][]string
+	counts    map[routeCount]int
+	present   map[routePresence]bool
+	vdoms     map[string]bool
+	// state of the VDOM currently decoded
+	cur     map[routeCount]int
+	curSeen map[string]bool
+}
+
+func newRouteCounter(ipVersion string, watched map[netip.Prefix][]string) *routeCounter {
+	return &routeCounter{
+		ipVersion: ipVersion,
+		watched:   watched,
+		counts:    make(map[routeCount]int),
+		present:   make(map[routePresence]bool),
+		vdoms:     make(map[string]bool),
+		cur:       make(map[routeCount]int),
+		curSeen:   make(map[string]bool),
+	}
+}
+
+func (rc *routeCounter) Entry(route Route) {
+	protocol := route.Type
+	if protocol == "connect" {
+		protocol = "connected"
+	}
+	rc.cur[routeCount{IPVersion: rc.ipVersion, Protocol: protocol, Interface: route.Interface}]++
+
+	if len(rc.watched) == 0 {
+		return
+	}
+	p, err := netip.ParsePrefix(route.IPMask)
+	if err != nil {
+		return
+	}
+	for _, name := range rc.watched[p.Masked()] {
+		rc.curSeen[name] = true
+	}
+}
+
+func (rc *routeCounter) EndVDOM(vdom string) {
+	rc.vdoms[vdom] = true
+	for k, count := range rc.cur {
+		k.VDOM = vdom
+		rc.counts[k] += count
+	}
+	for name := range rc.curSeen {
+		rc.present[routePresence{VDOM: vdom, Prefix: name}] = true
+	}
+	clear(rc.cur)
+	clear(rc.curSeen)
+}
+
+// newRouterRoutesProbe returns a probe counting the routes of the routing
+// tables and reporting whether the given prefixes are routed.
+func newRouterRoutesProbe(prefixes []string) probeFunc {
+	return func(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+		return probeRouterRoutes(c, prefixes)
+	}
+}
+
+func probeRouterRoutes(c http.FortiHTTP, prefixes []string) ([]prometheus.Metric, bool) {
+	var (
+		mRoutes = prometheus.NewDesc(
+			"fortigate_routes",
+			"Number of routes in the routing table",
+			[]string{"vdom", "ip_version", "protocol", "interface"}, nil,
+		)
+		mPresent = prometheus.NewDesc(
+			"fortigate_route_present",
+			"Whether a route for the watched prefix is in the routing table",
+			[]string{"vdom", "prefix"}, nil,
+		)
+		mTableEntries = prometheus.NewDesc(
+			"fortigate_route_table_entries",
+			"Number of entries in the routing table as reported by the router statistics",
+			[]string{"vdom", "ip_version"}, nil,
+		)
+	)
+
+	watched := map[netip.Prefix][]string{}
+	names := []string{}
+	for _, name := range prefixes {
+		p, err := netip.ParsePrefix(name)
+		if err != nil || slices.Contains(names, name) {
+			// Prefixes are validated when loading the configuration
+			continue
+		}
+		watched[p.Masked()] = append(watched[p.Masked()], name)
+		names = append(names, name)
+	}
+
+	maxEntries := config.GetConfig().MaxListEntries
+	m := []prometheus.Metric{}
+	truncated := false
+	vdoms := map[string]bool{}
+	present := map[routePresence]bool{}
+	for _, t := range []struct {
+		path      string
+		ipVersion int
+	}{
+		{"api/v2/monitor/router/ipv4", 4},
+		{"api/v2/monitor/router/ipv6", 6},
+	} {
+		counter := newRouteCounter(strconv.Itoa(t.ipVersion), watched)
+		tr, err := http.VisitAll[Route](c, t.path, "vdom=*", maxEntries, counter)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			return nil, false
+		}
+		if tr {
+			log.Printf("Warning: Received more IPv%d routes than maximum (%d) allowed, route counts are incomplete", t.ipVersion, maxEntries)
+			truncated = true
+		}
+		for k, count := range counter.counts {
+			m = append(m, prometheus.MustNewConstMetric(mRoutes, prometheus.GaugeValue, float64(count), k.VDOM, k.IPVersion, k.Protocol, k.Interface))
+		}
+		for vdom := range counter.vdoms {
+			vdoms[vdom] = true
+		}
+		for k := range counter.present {
+			present[k] = true
+		}
+	}
+	m = append(m, probeTruncated("Router/Routes", truncated))
+
+	for vdom := range vdoms {
+		for _, name := range names {
+			v := 0.0
+			if present[routePresence{VDOM: vdom, Prefix: name}] {
+				v = 1.0
+			}
+			m = append(m, prometheus.MustNewConstMetric(mPresent, prometheus.GaugeValue, v, vdom, name))
+		}
+	}
+
+	// The statistics are not available on all versions, so only log failures
+	var stats []RouterStatistics
+	if err := c.Get("api/v2/monitor/router/statistics", "vdom=*", &stats); err != nil {
+		log.Printf("Error: Failed to get router statistics: %v", err)
+		return m, true
+	}
+	for _, s := range stats {
+		m = append(m, prometheus.MustNewConstMetric(mTableEntries, prometheus.GaugeValue, float64(s.Results.TotalLinesIPv4), s.VDOM, "4"))
+		m = append(m, prometheus.MustNewConstMetric(mTableEntries, prometheus.GaugeValue, float64(s.Results.TotalLinesIPv6), s.VDOM, "6"))
+	}
+
+	return m, true
+}
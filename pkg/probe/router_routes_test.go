@@ -0,0 +1,76 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestRouterRoutes(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/router/ipv4", "testdata/router-ipv4.jsonnet")
+	c.prepare("api/v2/monitor/router/ipv6", "testdata/router-ipv6.jsonnet")
+	c.prepare("api/v2/monitor/router/statistics", "testdata/router.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	pf := newRouterRoutesProbe([]string{"0.0.0.0/0", "10.99.0.0/16", "10.98.0.0/16", "2001:db8::/64"})
+	if !testProbe(pf, c, r) {
+		t.Errorf("probeRouterRoutes() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Router/Routes"} 0
+	# HELP fortigate_route_present Whether a route for the watched prefix is in the routing table
+	# TYPE fortigate_route_present gauge
+	fortigate_route_present{prefix="0.0.0.0/0",vdom="FG-traffic"} 0
+	fortigate_route_present{prefix="0.0.0.0/0",vdom="root"} 1
+	fortigate_route_present{prefix="10.98.0.0/16",vdom="FG-traffic"} 0
+	fortigate_route_present{prefix="10.98.0.0/16",vdom="root"} 0
+	fortigate_route_present{prefix="10.99.0.0/16",vdom="FG-traffic"} 0
+	fortigate_route_present{prefix="10.99.0.0/16",vdom="root"} 1
+	fortigate_route_present{prefix="2001:db8::/64",vdom="FG-traffic"} 0
+	fortigate_route_present{prefix="2001:db8::/64",vdom="root"} 1
+	# HELP fortigate_route_table_entries Number of entries in the routing table as reported by the router statistics
+	# TYPE fortigate_route_table_entries gauge
+	fortigate_route_table_entries{ip_version="4",vdom="FG-traffic"} 0
+	fortigate_route_table_entries{ip_version="4",vdom="root"} 2
+	fortigate_route_table_entries{ip_version="6",vdom="FG-traffic"} 1
+	fortigate_route_table_entries{ip_version="6",vdom="root"} 3
+	# HELP fortigate_routes Number of routes in the routing table
+	# TYPE fortigate_routes gauge
+	fortigate_routes{interface="port1",ip_version="4",protocol="connected",vdom="root"} 1
+	fortigate_routes{interface="port1",ip_version="4",protocol="static",vdom="root"} 1
+	fortigate_routes{interface="port1",ip_version="6",protocol="connected",vdom="root"} 1
+	fortigate_routes{interface="port1",ip_version="6",protocol="static",vdom="root"} 1
+	fortigate_routes{interface="port2",ip_version="4",protocol="connected",vdom="root"} 1
+	fortigate_routes{interface="port2",ip_version="4",protocol="ospf",vdom="root"} 2
+	fortigate_routes{interface="port3",ip_version="4",protocol="connected",vdom="FG-traffic"} 1
+	fortigate_routes{interface="root",ip_version="4",protocol="kernel",vdom="root"} 1
+	fortigate_routes{interface="vpn-hub",ip_version="4",protocol="bgp",vdom="root"} 2
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type SecurityEventStats struct {
+	Detected float64 `json:"detected"`
+	Blocked  float64 `json:"blocked"`
+}
+
+type AntivirusStatsResponse struct {
+	Results map[string]SecurityEventStats `json:"results"`
+	VDOM    string                        `json:"vdom"`
+}
+
+type IPSStatsResponse struct {
+	Results []struct {
+		Severity string `json:"severity"`
+		SecurityEventStats
+	} `json:"results"`
+	VDOM string `json:"vdom"`
+}
+
+type CategoryStatsResponse struct {
+	Results []struct {
+		Category string  `json:"category"`
+		Blocked  float64 `json:"blocked"`
+	} `json:"results"`
+	VDOM string `json:"vdom"`
+}
+
+func probeSecurityAntivirus(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+	if meta.VersionMajor < 7 {
+		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
+		return nil, true
+	}
+	var (
+		mDetected = prometheus.NewDesc(
+			"fortigate_security_antivirus_detected_total",
+			"Number of viruses detected by protocol",
+			[]string{"vdom", "protocol"}, nil,
+		)
+		mBlocked = prometheus.NewDesc(
+			"fortigate_security_antivirus_blocked_total",
+			"Number of viruses blocked by protocol",
+			[]string{"vdom", "protocol"}, nil,
+		)
+	)
+
+	var res []AntivirusStatsResponse
+	if err := c.Get("api/v2/monitor/utm/antivirus/stats", "vdom=*", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		for protocol, s := range r.Results {
+			m = append(m, prometheus.MustNewConstMetric(mDetected, prometheus.CounterValue, s.Detected, r.VDOM, protocol))
+			m = append(m, prometheus.MustNewConstMetric(mBlocked, prometheus.CounterValue, s.Blocked, r.VDOM, protocol))
+		}
+	}
+
+	return m, true
+}
+
+func probeSecurityIPS(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+	if meta.VersionMajor < 7 {
+		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
+		return nil, true
+	}
+	var (
+		mDetected = prometheus.NewDesc(
+			"fortigate_security_ips_detected_total",
+			"Number of attacks detected by IPS by severity",
+			[]string{"vdom", "severity"}, nil,
+		)
+		mBlocked = prometheus.NewDesc(
+			"fortigate_security_ips_blocked_total",
+			"Number of attacks blocked by IPS by severity",
+			[]string{"vdom", "severity"}, nil,
+		)
+	)
+
+	var res []IPSStatsResponse
+	if err := c.Get("api/v2/monitor/utm/ips/stats", "vdom=*", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		for _, s := range r.Results {
+			m = append(m, prometheus.MustNewConstMetric(mDetected, prometheus.CounterValue, s.Detected, r.VDOM, s.Severity))
+			m = append(m, prometheus.MustNewConstMetric(mBlocked, prometheus.CounterValue, s.Blocked, r.VDOM, s.Severity))
+		}
+	}
+
+	return m, true
+}
+
+// securityCategories keeps, per target and probe, the categories the
+// Security probes report on their own. They are kept across scrapes so that
+// the overflow counter only ever sums up the same categories.
+var securityCategories = struct {
+	sync.Mutex
+	sets map[string]*categorySet
+}{sets: map[string]*categorySet{}}
+
+// categorySet holds the categories reported per VDOM in the order they were
+// first seen.
+type categorySet struct {
+	sync.Mutex
+	vdoms map[string]map[string]bool
+}
+
+func newCategorySet() *categorySet {
+	return &categorySet{vdoms: map[string]map[string]bool{}}
+}
+
+// securityCategorySet returns the category set of the named probe of a target,
+// creating it on the first scrape of the target.
+func securityCategorySet(target, probe string) *categorySet {
+	securityCategories.Lock()
+	defer securityCategories.Unlock()
+	key := target + " " + probe
+	if s, ok := securityCategories.sets[key]; ok {
+		return s
+	}
+	s := newCategorySet()
+	securityCategories.sets[key] = s
+	return s
+}
+
+// admit tells whether the category is reported on its own. Categories are
+// admitted in the order they are seen until limit categories are known for
+// the VDOM, a limit of 0 admits all of them.
+func (s *categorySet) admit(vdom, category string, limit int) bool {
+	s.Lock()
+	defer s.Unlock()
+	known, ok := s.vdoms[vdom]
+	if !ok {
+		known = map[string]bool{}
+		s.vdoms[vdom] = known
+	}
+	if known[category] {
+		return true
+	}
+	if limit > 0 && len(known) >= limit {
+		return false
+	}
+	known[category] = true
+	return true
+}
+
+func newSecurityWebFilterProbe(categories *categorySet) probeFunc {
+	return func(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+		return probeSecurityWebFilter(c, meta, categories)
+	}
+}
+
+func probeSecurityWebFilter(c http.FortiHTTP, meta *TargetMetadata, categories *categorySet) ([]prometheus.Metric, bool) {
+	if meta.VersionMajor < 7 {
+		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
+		return nil, true
+	}
+	var (
+		mBlocked = prometheus.NewDesc(
+			"fortigate_security_webfilter_blocked_total",
+			"Number of requests blocked by the web filter by category",
+			[]string{"vdom", "category"}, nil,
+		)
+		mOverflow = prometheus.NewDesc(
+			"fortigate_security_webfilter_overflow_blocked_total",
+			"Number of requests blocked by the web filter in categories beyond -max-security-categories",
+			[]string{"vdom"}, nil,
+		)
+	)
+	return probeSecurityCategories(c, "api/v2/monitor/utm/webfilter/stats", categories, mBlocked, mOverflow)
+}
+
+func newSecurityAppControlProbe(categories *categorySet) probeFunc {
+	return func(c http.FortiHTTP, meta *TargetMetadata) ([]prometheus.Metric, bool) {
+		return probeSecurityAppControl(c, meta, categories)
+	}
+}
+
+func probeSecurityAppControl(c http.FortiHTTP, meta *TargetMetadata, categories *categorySet) ([]prometheus.Metric, bool) {
+	if meta.VersionMajor < 7 {
+		// not supported version. Before 7.0.0 the requested endpoint doesn't exist
+		return nil, true
+	}
+	var (
+		mBlocked = prometheus.NewDesc(
+			"fortigate_security_app_control_blocked_total",
+			"Number of sessions blocked by application control by category",
+			[]string{"vdom", "category"}, nil,
+		)
+		mOverflow = prometheus.NewDesc(
+			"fortigate_security_app_control_overflow_blocked_total",
+			"Number of sessions blocked by application control in categories beyond -max-security-categories",
+			[]string{"vdom"}, nil,
+		)
+	)
+	return probeSecurityCategories(c, "api/v2/monitor/utm/app-ctrl/stats", categories, mBlocked, mOverflow)
+}
+
+// probeSecurityCategories reports the blocked events per category for the
+// categories admitted by the category set. Blocked events of all other
+// categories are summed up by the overflow counter, which is reported for
+// every VDOM when a limit is set.
+func probeSecurityCategories(c http.FortiHTTP, path string, categories *categorySet, desc, overflowDesc *prometheus.Desc) ([]prometheus.Metric, bool) {
+	var res []CategoryStatsResponse
+	if err := c.Get(path, "vdom=*", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	limit := config.GetConfig().MaxSecurityCategories
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		blocked := map[string]float64{}
+		overflow := 0.0
+		for _, s := range r.Results {
+			if categories.admit(r.VDOM, s.Category, limit) {
+				blocked[s.Category] += s.Blocked
+			} else {
+				overflow += s.Blocked
+			}
+		}
+		for category, v := range blocked {
+			m = append(m, prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, r.VDOM, category))
+		}
+		if limit > 0 {
+			m = append(m, prometheus.MustNewConstMetric(overflowDesc, prometheus.CounterValue, overflow, r.VDOM))
+		}
+	}
+
+	return m, true
+}
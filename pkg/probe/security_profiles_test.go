@@ -0,0 +1,204 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestSecurityAntivirus(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/utm/antivirus/stats", "testdata/utm-antivirus-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSecurityAntivirus, c, r) {
+		t.Errorf("probeSecurityAntivirus() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_security_antivirus_blocked_total Number of viruses blocked by protocol
+	# TYPE fortigate_security_antivirus_blocked_total counter
+	fortigate_security_antivirus_blocked_total{protocol="ftp",vdom="root"} 0
+	fortigate_security_antivirus_blocked_total{protocol="http",vdom="root"} 40
+	fortigate_security_antivirus_blocked_total{protocol="smtp",vdom="root"} 7
+	# HELP fortigate_security_antivirus_detected_total Number of viruses detected by protocol
+	# TYPE fortigate_security_antivirus_detected_total counter
+	fortigate_security_antivirus_detected_total{protocol="ftp",vdom="root"} 0
+	fortigate_security_antivirus_detected_total{protocol="http",vdom="root"} 42
+	fortigate_security_antivirus_detected_total{protocol="smtp",vdom="root"} 7
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSecurityIPS(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/utm/ips/stats", "testdata/utm-ips-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSecurityIPS, c, r) {
+		t.Errorf("probeSecurityIPS() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_security_ips_blocked_total Number of attacks blocked by IPS by severity
+	# TYPE fortigate_security_ips_blocked_total counter
+	fortigate_security_ips_blocked_total{severity="critical",vdom="root"} 12
+	fortigate_security_ips_blocked_total{severity="high",vdom="root"} 50
+	fortigate_security_ips_blocked_total{severity="info",vdom="root"} 0
+	fortigate_security_ips_blocked_total{severity="low",vdom="root"} 0
+	fortigate_security_ips_blocked_total{severity="medium",vdom="root"} 20
+	# HELP fortigate_security_ips_detected_total Number of attacks detected by IPS by severity
+	# TYPE fortigate_security_ips_detected_total counter
+	fortigate_security_ips_detected_total{severity="critical",vdom="root"} 12
+	fortigate_security_ips_detected_total{severity="high",vdom="root"} 55
+	fortigate_security_ips_detected_total{severity="info",vdom="root"} 2000
+	fortigate_security_ips_detected_total{severity="low",vdom="root"} 800
+	fortigate_security_ips_detected_total{severity="medium",vdom="root"} 130
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSecurityWebFilter(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/utm/webfilter/stats", "testdata/utm-webfilter-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSecurityWebFilterProbe(newCategorySet()), c, r) {
+		t.Errorf("probeSecurityWebFilter() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_security_webfilter_blocked_total Number of requests blocked by the web filter by category
+	# TYPE fortigate_security_webfilter_blocked_total counter
+	fortigate_security_webfilter_blocked_total{category="Gambling",vdom="root"} 4
+	fortigate_security_webfilter_blocked_total{category="Malicious Websites",vdom="root"} 310
+	fortigate_security_webfilter_blocked_total{category="Phishing",vdom="root"} 120
+	fortigate_security_webfilter_blocked_total{category="Proxy Avoidance",vdom="root"} 9
+	fortigate_security_webfilter_blocked_total{category="Spam URLs",vdom="root"} 15
+	# HELP fortigate_security_webfilter_overflow_blocked_total Number of requests blocked by the web filter in categories beyond -max-security-categories
+	# TYPE fortigate_security_webfilter_overflow_blocked_total counter
+	fortigate_security_webfilter_overflow_blocked_total{vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSecurityWebFilterCategoryCap(t *testing.T) {
+	if err := flag.Set("max-security-categories", "3"); err != nil {
+		t.Fatalf("flag.Set failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = flag.Set("max-security-categories", "20")
+		config.MustReInit()
+	})
+	config.MustReInit()
+
+	categories := newCategorySet()
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/utm/webfilter/stats", "testdata/utm-webfilter-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSecurityWebFilterProbe(categories), c, r) {
+		t.Errorf("probeSecurityWebFilter() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_security_webfilter_blocked_total Number of requests blocked by the web filter by category
+	# TYPE fortigate_security_webfilter_blocked_total counter
+	fortigate_security_webfilter_blocked_total{category="Malicious Websites",vdom="root"} 310
+	fortigate_security_webfilter_blocked_total{category="Phishing",vdom="root"} 120
+	fortigate_security_webfilter_blocked_total{category="Spam URLs",vdom="root"} 15
+	# HELP fortigate_security_webfilter_overflow_blocked_total Number of requests blocked by the web filter in categories beyond -max-security-categories
+	# TYPE fortigate_security_webfilter_overflow_blocked_total counter
+	fortigate_security_webfilter_overflow_blocked_total{vdom="root"} 13
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+
+	// A later scrape sees a new category and a different order, the reported
+	// categories stay the same and the overflow counter keeps increasing
+	c = newFakeClient()
+	c.prepare("api/v2/monitor/utm/webfilter/stats", "testdata/utm-webfilter-stats-later.jsonnet")
+	r = prometheus.NewPedanticRegistry()
+	if !testProbe(newSecurityWebFilterProbe(categories), c, r) {
+		t.Errorf("probeSecurityWebFilter() returned non-success")
+	}
+
+	em = `
+	# HELP fortigate_security_webfilter_blocked_total Number of requests blocked by the web filter by category
+	# TYPE fortigate_security_webfilter_blocked_total counter
+	fortigate_security_webfilter_blocked_total{category="Malicious Websites",vdom="root"} 320
+	fortigate_security_webfilter_blocked_total{category="Phishing",vdom="root"} 125
+	fortigate_security_webfilter_blocked_total{category="Spam URLs",vdom="root"} 15
+	# HELP fortigate_security_webfilter_overflow_blocked_total Number of requests blocked by the web filter in categories beyond -max-security-categories
+	# TYPE fortigate_security_webfilter_overflow_blocked_total counter
+	fortigate_security_webfilter_overflow_blocked_total{vdom="root"} 159
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSecurityAppControl(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/utm/app-ctrl/stats", "testdata/utm-app-ctrl-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSecurityAppControlProbe(newCategorySet()), c, r) {
+		t.Errorf("probeSecurityAppControl() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_security_app_control_blocked_total Number of sessions blocked by application control by category
+	# TYPE fortigate_security_app_control_blocked_total counter
+	fortigate_security_app_control_blocked_total{category="Game",vdom="root"} 3
+	fortigate_security_app_control_blocked_total{category="P2P",vdom="root"} 77
+	fortigate_security_app_control_blocked_total{category="Proxy",vdom="root"} 31
+	# HELP fortigate_security_app_control_overflow_blocked_total Number of sessions blocked by application control in categories beyond -max-security-categories
+	# TYPE fortigate_security_app_control_overflow_blocked_total counter
+	fortigate_security_app_control_overflow_blocked_total{vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSecurityPre7(t *testing.T) {
+	for name, pf := range map[string]probeFunc{
+		"probeSecurityAntivirus":  probeSecurityAntivirus,
+		"probeSecurityIPS":        probeSecurityIPS,
+		"probeSecurityWebFilter":  newSecurityWebFilterProbe(newCategorySet()),
+		"probeSecurityAppControl": newSecurityAppControlProbe(newCategorySet()),
+	} {
+		// No prepared responses, the probes must not request anything
+		m, ok := pf(newFakeClient(), &TargetMetadata{VersionMajor: 6, VersionMinor: 4})
+		if !ok || len(m) != 0 {
+			t.Errorf("%s() returned %d metrics, %v, expected none and success on FortiOS 6.4", name, len(m), ok)
+		}
+	}
+}
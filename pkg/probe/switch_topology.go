@@ -0,0 +1,160 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type DetectedDevice struct {
+	MAC      string `json:"mac"`
+	SwitchID string `json:"switch_id"`
+	Port     string `json:"port_name"`
+}
+
+type SwitchPortHealth struct {
+	SwitchID      string         `json:"switch_id"`
+	Port          string         `json:"port"`
+	STPState      string         `json:"stp_state"`
+	LinkFlapCount float64        `json:"link_flap_count"`
+	LLDPNeighbors []LLDPNeighbor `json:"lldp_neighbors"`
+}
+
+type SwitchPortHealthResponse struct {
+	Results []SwitchPortHealth `json:"results"`
+	VDOM    string             `json:"vdom"`
+}
+
+// switchSTPStates are always reported per port, the active one with value 1
+var switchSTPStates = []string{"disabled", "discarding", "learning", "forwarding"}
+
+type switchPort struct {
+	VDOM   string
+	Switch string
+	Port   string
+}
+
+// macCounter counts the learned MAC addresses per switch port while they
+// are decoded.
+type macCounter struct {
+	counts map[switchPort]int
+	// counts of the VDOM currently decoded, with an empty VDOM in the key
+	cur map[switchPort]int
+}
+
+func newMACCounter() *macCounter {
+	return &macCounter{
+		counts: make(map[switchPort]int),
+		cur:    make(map[switchPort]int),
+	}
+}
+
+func (mc *macCounter) Entry(d DetectedDevice) {
+	mc.cur[switchPort{Switch: d.SwitchID, Port: d.Port}]++
+}
+
+func (mc *macCounter) EndVDOM(vdom string) {
+	for k, count := range mc.cur {
+		k.VDOM = vdom
+		mc.counts[k] += count
+	}
+	clear(mc.cur)
+}
+
+func probeSwitchTopology(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mMACs = prometheus.NewDesc(
+			"fortigate_managed_switch_port_learned_macs",
+			"Number of MAC addresses learned on the switch port",
+			[]string{"vdom", "switch_name", "port"}, nil,
+		)
+		mLLDP = prometheus.NewDesc(
+			"fortigate_managed_switch_port_lldp_neighbor_info",
+			"LLDP neighbor seen on the switch port",
+			[]string{"vdom", "switch_name", "port", "chassis_id", "system_name", "port_id"}, nil,
+		)
+		mLLDPNeighbors = prometheus.NewDesc(
+			"fortigate_managed_switch_port_lldp_neighbors",
+			"Number of LLDP neighbors seen on the switch port",
+			[]string{"vdom", "switch_name", "port"}, nil,
+		)
+		mSTPState = prometheus.NewDesc(
+			"fortigate_managed_switch_port_stp_state",
+			"Spanning tree state of the switch port, 1 for the current state",
+			[]string{"vdom", "switch_name", "port", "state"}, nil,
+		)
+		mFlaps = prometheus.NewDesc(
+			"fortigate_managed_switch_port_link_flaps_total",
+			"Number of link state changes of the switch port",
+			[]string{"vdom", "switch_name", "port"}, nil,
+		)
+	)
+
+	var health []SwitchPortHealthResponse
+	if err := c.Get("api/v2/monitor/switch-controller/managed-switch/port-health", "vdom=*", &health); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	maxEntries := config.GetConfig().MaxListEntries
+	macs := newMACCounter()
+	truncated, err := http.VisitAll[DetectedDevice](c, "api/v2/monitor/switch-controller/detected-device", "vdom=*", maxEntries, macs)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more detected devices than maximum (%d) allowed, MAC counts are incomplete", maxEntries)
+	}
+
+	m := []prometheus.Metric{probeTruncated("Switch/Topology", truncated)}
+	for _, r := range health {
+		for _, p := range r.Results {
+			// Ports without learned MAC addresses are not in the detected devices
+			k := switchPort{VDOM: r.VDOM, Switch: p.SwitchID, Port: p.Port}
+			if _, ok := macs.counts[k]; !ok {
+				macs.counts[k] = 0
+			}
+
+			for _, n := range p.LLDPNeighbors {
+				m = append(m, prometheus.MustNewConstMetric(mLLDP, prometheus.GaugeValue, 1, r.VDOM, p.SwitchID, p.Port, n.ChassisID, n.SystemName, n.PortID))
+			}
+			m = append(m, prometheus.MustNewConstMetric(mLLDPNeighbors, prometheus.GaugeValue, float64(len(p.LLDPNeighbors)), r.VDOM, p.SwitchID, p.Port))
+
+			for _, state := range switchSTPStates {
+				v := 0.0
+				if p.STPState == state {
+					v = 1
+				}
+				m = append(m, prometheus.MustNewConstMetric(mSTPState, prometheus.GaugeValue, v, r.VDOM, p.SwitchID, p.Port, state))
+			}
+			if p.STPState != "" && !slices.Contains(switchSTPStates, p.STPState) {
+				m = append(m, prometheus.MustNewConstMetric(mSTPState, prometheus.GaugeValue, 1, r.VDOM, p.SwitchID, p.Port, p.STPState))
+			}
+
+			m = append(m, prometheus.MustNewConstMetric(mFlaps, prometheus.CounterValue, p.LinkFlapCount, r.VDOM, p.SwitchID, p.Port))
+		}
+	}
+	for k, count := range macs.counts {
+		m = append(m, prometheus.MustNewConstMetric(mMACs, prometheus.GaugeValue, float64(count), k.VDOM, k.Switch, k.Port))
+	}
+
+	return m, true
+}
@@ -0,0 +1,88 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestSwitchTopology(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/switch-controller/managed-switch/port-health", "testdata/switch-controller-port-health.jsonnet")
+	c.prepare("api/v2/monitor/switch-controller/detected-device", "testdata/switch-controller-detected-device.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSwitchTopology, c, r) {
+		t.Errorf("probeSwitchTopology() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_managed_switch_port_learned_macs Number of MAC addresses learned on the switch port
+	# TYPE fortigate_managed_switch_port_learned_macs gauge
+	fortigate_managed_switch_port_learned_macs{port="port1",switch_name="S124EN5918003682",vdom="root"} 2
+	fortigate_managed_switch_port_learned_macs{port="port2",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_learned_macs{port="port3",switch_name="S124EN5918003682",vdom="root"} 3
+	fortigate_managed_switch_port_learned_macs{port="port4",switch_name="S124EN5918003682",vdom="root"} 0
+	# HELP fortigate_managed_switch_port_link_flaps_total Number of link state changes of the switch port
+	# TYPE fortigate_managed_switch_port_link_flaps_total counter
+	fortigate_managed_switch_port_link_flaps_total{port="port1",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_link_flaps_total{port="port2",switch_name="S124EN5918003682",vdom="root"} 3
+	fortigate_managed_switch_port_link_flaps_total{port="port3",switch_name="S124EN5918003682",vdom="root"} 41
+	fortigate_managed_switch_port_link_flaps_total{port="port4",switch_name="S124EN5918003682",vdom="root"} 1
+	# HELP fortigate_managed_switch_port_lldp_neighbor_info LLDP neighbor seen on the switch port
+	# TYPE fortigate_managed_switch_port_lldp_neighbor_info gauge
+	fortigate_managed_switch_port_lldp_neighbor_info{chassis_id="00:09:0f:00:00:01",port="port2",port_id="lan1",switch_name="S124EN5918003682",system_name="FP231F-Lobby",vdom="root"} 1
+	fortigate_managed_switch_port_lldp_neighbor_info{chassis_id="70:4c:a5:00:00:02",port="port3",port_id="Gi0/1",switch_name="S124EN5918003682",system_name="rogue-switch",vdom="root"} 1
+	# HELP fortigate_managed_switch_port_lldp_neighbors Number of LLDP neighbors seen on the switch port
+	# TYPE fortigate_managed_switch_port_lldp_neighbors gauge
+	fortigate_managed_switch_port_lldp_neighbors{port="port1",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_lldp_neighbors{port="port2",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_lldp_neighbors{port="port3",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_lldp_neighbors{port="port4",switch_name="S124EN5918003682",vdom="root"} 0
+	# HELP fortigate_managed_switch_port_stp_state Spanning tree state of the switch port, 1 for the current state
+	# TYPE fortigate_managed_switch_port_stp_state gauge
+	fortigate_managed_switch_port_stp_state{port="port1",state="disabled",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port1",state="discarding",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port1",state="forwarding",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_stp_state{port="port1",state="learning",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port2",state="disabled",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port2",state="discarding",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port2",state="forwarding",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_stp_state{port="port2",state="learning",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port3",state="disabled",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port3",state="discarding",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_stp_state{port="port3",state="forwarding",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port3",state="learning",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port4",state="disabled",switch_name="S124EN5918003682",vdom="root"} 1
+	fortigate_managed_switch_port_stp_state{port="port4",state="discarding",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port4",state="forwarding",switch_name="S124EN5918003682",vdom="root"} 0
+	fortigate_managed_switch_port_stp_state{port="port4",state="learning",switch_name="S124EN5918003682",vdom="root"} 0
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Switch/Topology"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type AdminSession struct {
+	Admin   string `json:"admin"`
+	SrcIP   string `json:"srcip"`
+	Method  string `json:"method"`
+	Profile string `json:"profile"`
+}
+
+type AdminSessionResponse struct {
+	Results []AdminSession `json:"results"`
+}
+
+type adminSessionCount struct {
+	Profile string
+	Method  string
+}
+
+func probeSystemAdmins(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	mSessions := prometheus.NewDesc(
+		"fortigate_admin_sessions",
+		"Number of logged in administrator sessions",
+		[]string{"profile", "method"}, nil,
+	)
+
+	var res AdminSessionResponse
+	if err := c.Get("api/v2/monitor/system/current-admins", "", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	counts := map[adminSessionCount]int{}
+	for _, a := range res.Results {
+		counts[adminSessionCount{Profile: a.Profile, Method: a.Method}]++
+	}
+
+	m := []prometheus.Metric{}
+	for k, count := range counts {
+		m = append(m, prometheus.MustNewConstMetric(mSessions, prometheus.GaugeValue, float64(count), k.Profile, k.Method))
+	}
+
+	return m, true
+}
+
+// probeSystemAdminsInfo reports every administrator session on its own, it is
+// a separate probe to allow excluding it if there are many sessions.
+func probeSystemAdminsInfo(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	mInfo := prometheus.NewDesc(
+		"fortigate_admin_session_info",
+		"Logged in administrator session",
+		[]string{"name", "source", "method", "profile"}, nil,
+	)
+
+	var res AdminSessionResponse
+	if err := c.Get("api/v2/monitor/system/current-admins", "", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	// An administrator may be logged in several times from the same address
+	sessions := map[AdminSession]int{}
+	for _, a := range res.Results {
+		sessions[a]++
+	}
+
+	m := []prometheus.Metric{}
+	for a, count := range sessions {
+		m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, float64(count), a.Admin, a.SrcIP, a.Method, a.Profile))
+	}
+
+	return m, true
+}
@@ -0,0 +1,64 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemAdmins(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/current-admins", "testdata/system-current-admins.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemAdmins, c, r) {
+		t.Errorf("probeSystemAdmins() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_admin_sessions Number of logged in administrator sessions
+	# TYPE fortigate_admin_sessions gauge
+	fortigate_admin_sessions{method="console",profile="super_admin"} 1
+	fortigate_admin_sessions{method="https",profile="super_admin"} 2
+	fortigate_admin_sessions{method="ssh",profile="prof_admin"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSystemAdminsInfo(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/current-admins", "testdata/system-current-admins.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemAdminsInfo, c, r) {
+		t.Errorf("probeSystemAdminsInfo() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_admin_session_info Logged in administrator session
+	# TYPE fortigate_admin_session_info gauge
+	fortigate_admin_session_info{method="console",name="admin",profile="super_admin",source=""} 1
+	fortigate_admin_session_info{method="https",name="admin",profile="super_admin",source="10.0.0.5"} 2
+	fortigate_admin_session_info{method="ssh",name="netops",profile="prof_admin",source="192.0.2.17"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
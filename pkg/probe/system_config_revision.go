@@ -0,0 +1,101 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type ConfigRevision struct {
+	ID    int64  `json:"id"`
+	Time  int64  `json:"time"`
+	Admin string `json:"admin"`
+}
+
+type ConfigRevisionResponse struct {
+	Results struct {
+		Revisions            []ConfigRevision `json:"revisions"`
+		CurrentConfigUnsaved bool             `json:"current_config_unsaved"`
+	} `json:"results"`
+}
+
+func probeSystemConfigRevision(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mID = prometheus.NewDesc(
+			"fortigate_config_revision_latest_id",
+			"Id of the latest saved configuration revision",
+			nil, nil,
+		)
+		mTime = prometheus.NewDesc(
+			"fortigate_config_revision_latest_timestamp_seconds",
+			"Time the latest configuration revision was saved",
+			nil, nil,
+		)
+		mInfo = prometheus.NewDesc(
+			"fortigate_config_revision_latest_info",
+			"Administrator who saved the latest configuration revision",
+			[]string{"admin"}, nil,
+		)
+		mRecent = prometheus.NewDesc(
+			"fortigate_config_revisions_last_24h",
+			"Number of configuration revisions saved in the last 24 hours",
+			nil, nil,
+		)
+		mUnsaved = prometheus.NewDesc(
+			"fortigate_config_unsaved",
+			"Whether the running configuration has changes not saved as revision",
+			nil, nil,
+		)
+	)
+
+	var res ConfigRevisionResponse
+	if err := c.Get("api/v2/monitor/system/config-revision", "", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	since := timeNow().Add(-24 * time.Hour).Unix()
+	recent := 0
+	var latest *ConfigRevision
+	for i, r := range res.Results.Revisions {
+		if r.Time >= since {
+			recent++
+		}
+		if latest == nil || r.ID > latest.ID {
+			latest = &res.Results.Revisions[i]
+		}
+	}
+
+	unsaved := 0.0
+	if res.Results.CurrentConfigUnsaved {
+		unsaved = 1
+	}
+
+	m := []prometheus.Metric{
+		prometheus.MustNewConstMetric(mRecent, prometheus.GaugeValue, float64(recent)),
+		prometheus.MustNewConstMetric(mUnsaved, prometheus.GaugeValue, unsaved),
+	}
+	if latest != nil {
+		m = append(m, prometheus.MustNewConstMetric(mID, prometheus.GaugeValue, float64(latest.ID)))
+		m = append(m, prometheus.MustNewConstMetric(mTime, prometheus.GaugeValue, float64(latest.Time)))
+		m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, latest.Admin))
+	}
+
+	return m, true
+}
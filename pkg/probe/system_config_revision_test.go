@@ -0,0 +1,58 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemConfigRevision(t *testing.T) {
+	// One day after the first revision, shortly after the second one
+	timeNow = func() time.Time { return time.Unix(1590345600, 0) }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/config-revision", "testdata/config-revision.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemConfigRevision, c, r) {
+		t.Errorf("probeSystemConfigRevision() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_config_revision_latest_id Id of the latest saved configuration revision
+	# TYPE fortigate_config_revision_latest_id gauge
+	fortigate_config_revision_latest_id 2
+	# HELP fortigate_config_revision_latest_info Administrator who saved the latest configuration revision
+	# TYPE fortigate_config_revision_latest_info gauge
+	fortigate_config_revision_latest_info{admin="bluecmd"} 1
+	# HELP fortigate_config_revision_latest_timestamp_seconds Time the latest configuration revision was saved
+	# TYPE fortigate_config_revision_latest_timestamp_seconds gauge
+	fortigate_config_revision_latest_timestamp_seconds 1.590345408e+09
+	# HELP fortigate_config_revisions_last_24h Number of configuration revisions saved in the last 24 hours
+	# TYPE fortigate_config_revisions_last_24h gauge
+	fortigate_config_revisions_last_24h 1
+	# HELP fortigate_config_unsaved Whether the running configuration has changes not saved as revision
+	# TYPE fortigate_config_unsaved gauge
+	fortigate_config_unsaved 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"encoding/binary"
+	"log"
+	"net/netip"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type DHCPLease struct {
+	IP         string `json:"ip"`
+	Reserved   bool   `json:"reserved"`
+	ExpireTime int64  `json:"expire_time"`
+	Interface  string `json:"interface"`
+	Type       string `json:"type"`
+	ServerID   int    `json:"server_mkey"`
+}
+
+type DHCPLeaseResponse struct {
+	Results []DHCPLease `json:"results"`
+	VDOM    string      `json:"vdom"`
+}
+
+type DHCPIPRange struct {
+	StartIP string `json:"start-ip"`
+	EndIP   string `json:"end-ip"`
+}
+
+type DHCPServer struct {
+	ID        int           `json:"id"`
+	Interface string        `json:"interface"`
+	LeaseTime int64         `json:"lease-time"`
+	IPRange   []DHCPIPRange `json:"ip-range"`
+}
+
+type DHCPServerResponse struct {
+	Results []DHCPServer `json:"results"`
+	VDOM    string       `json:"vdom"`
+}
+
+type dhcpScope struct {
+	vdom      string
+	iface     string
+	id        string
+	leaseTime int64
+	size      float64
+	active    float64
+	reserved  float64
+	expiring  float64
+}
+
+func probeSystemDHCP(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mActive = prometheus.NewDesc(
+			"fortigate_dhcp_leases_active",
+			"Number of active leases of the DHCP server",
+			[]string{"vdom", "interface", "server_id"}, nil,
+		)
+		mReserved = prometheus.NewDesc(
+			"fortigate_dhcp_leases_reserved",
+			"Number of active leases for reserved addresses of the DHCP server",
+			[]string{"vdom", "interface", "server_id"}, nil,
+		)
+		mExpiring = prometheus.NewDesc(
+			"fortigate_dhcp_leases_expiring",
+			"Number of active leases past their renewal time (less than half of the lease time left) of the DHCP server",
+			[]string{"vdom", "interface", "server_id"}, nil,
+		)
+		mSize = prometheus.NewDesc(
+			"fortigate_dhcp_pool_size",
+			"Number of addresses in the IP ranges of the DHCP server",
+			[]string{"vdom", "interface", "server_id"}, nil,
+		)
+		mUtilization = prometheus.NewDesc(
+			"fortigate_dhcp_pool_utilization_ratio",
+			"Ratio of active leases to addresses in the IP ranges of the DHCP server (0 - 1.0)",
+			[]string{"vdom", "interface", "server_id"}, nil,
+		)
+	)
+
+	var servers []DHCPServerResponse
+	if err := c.Get("api/v2/cmdb/system.dhcp/server", "vdom=*", &servers); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	var leases []DHCPLeaseResponse
+	if err := c.Get("api/v2/monitor/system/dhcp", "vdom=*", &leases); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	scopes := []*dhcpScope{}
+	byID := map[string]map[int]*dhcpScope{}
+	byInterface := map[string]map[string]*dhcpScope{}
+	for _, r := range servers {
+		byID[r.VDOM] = map[int]*dhcpScope{}
+		byInterface[r.VDOM] = map[string]*dhcpScope{}
+		for _, s := range r.Results {
+			scope := &dhcpScope{
+				vdom:      r.VDOM,
+				iface:     s.Interface,
+				id:        strconv.Itoa(s.ID),
+				leaseTime: s.LeaseTime,
+			}
+			for _, ipr := range s.IPRange {
+				scope.size += dhcpRangeSize(ipr)
+			}
+			scopes = append(scopes, scope)
+			byID[r.VDOM][s.ID] = scope
+			byInterface[r.VDOM][s.Interface] = scope
+		}
+	}
+
+	now := timeNow().Unix()
+	for _, r := range leases {
+		for _, l := range r.Results {
+			// DHCPv6 servers are configured in system.dhcp6/server
+			if l.Type != "" && l.Type != "ipv4" {
+				continue
+			}
+			scope, ok := byID[r.VDOM][l.ServerID]
+			if !ok {
+				scope, ok = byInterface[r.VDOM][l.Interface]
+			}
+			if !ok {
+				continue
+			}
+			scope.active++
+			if l.Reserved {
+				scope.reserved++
+			}
+			if scope.leaseTime > 0 && l.ExpireTime-now < scope.leaseTime/2 {
+				scope.expiring++
+			}
+		}
+	}
+
+	m := []prometheus.Metric{}
+	for _, s := range scopes {
+		m = append(m, prometheus.MustNewConstMetric(mActive, prometheus.GaugeValue, s.active, s.vdom, s.iface, s.id))
+		m = append(m, prometheus.MustNewConstMetric(mReserved, prometheus.GaugeValue, s.reserved, s.vdom, s.iface, s.id))
+		m = append(m, prometheus.MustNewConstMetric(mExpiring, prometheus.GaugeValue, s.expiring, s.vdom, s.iface, s.id))
+		m = append(m, prometheus.MustNewConstMetric(mSize, prometheus.GaugeValue, s.size, s.vdom, s.iface, s.id))
+		if s.size > 0 {
+			m = append(m, prometheus.MustNewConstMetric(mUtilization, prometheus.GaugeValue, s.active/s.size, s.vdom, s.iface, s.id))
+		}
+	}
+
+	return m, true
+}
+
+// dhcpRangeSize returns the number of addresses in an IPv4 range, 0 if invalid
+func dhcpRangeSize(r DHCPIPRange) float64 {
+	start, err := netip.ParseAddr(r.StartIP)
+	if err != nil || !start.Is4() {
+		return 0
+	}
+	end, err := netip.ParseAddr(r.EndIP)
+	if err != nil || !end.Is4() || end.Less(start) {
+		return 0
+	}
+	s, e := start.As4(), end.As4()
+	return float64(binary.BigEndian.Uint32(e[:]) - binary.BigEndian.Uint32(s[:]) + 1)
+}
@@ -0,0 +1,68 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemDHCP(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1700000000, 0) }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	c := newFakeClient()
+	c.prepare("api/v2/cmdb/system.dhcp/server", "testdata/system-dhcp-server.jsonnet")
+	c.prepare("api/v2/monitor/system/dhcp", "testdata/system-dhcp.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemDHCP, c, r) {
+		t.Errorf("probeSystemDHCP() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_dhcp_leases_active Number of active leases of the DHCP server
+	# TYPE fortigate_dhcp_leases_active gauge
+	fortigate_dhcp_leases_active{interface="guest",server_id="2",vdom="root"} 2
+	fortigate_dhcp_leases_active{interface="internal",server_id="1",vdom="branch"} 0
+	fortigate_dhcp_leases_active{interface="port2",server_id="1",vdom="root"} 3
+	# HELP fortigate_dhcp_leases_expiring Number of active leases past their renewal time (less than half of the lease time left) of the DHCP server
+	# TYPE fortigate_dhcp_leases_expiring gauge
+	fortigate_dhcp_leases_expiring{interface="guest",server_id="2",vdom="root"} 1
+	fortigate_dhcp_leases_expiring{interface="internal",server_id="1",vdom="branch"} 0
+	fortigate_dhcp_leases_expiring{interface="port2",server_id="1",vdom="root"} 1
+	# HELP fortigate_dhcp_leases_reserved Number of active leases for reserved addresses of the DHCP server
+	# TYPE fortigate_dhcp_leases_reserved gauge
+	fortigate_dhcp_leases_reserved{interface="guest",server_id="2",vdom="root"} 0
+	fortigate_dhcp_leases_reserved{interface="internal",server_id="1",vdom="branch"} 0
+	fortigate_dhcp_leases_reserved{interface="port2",server_id="1",vdom="root"} 1
+	# HELP fortigate_dhcp_pool_size Number of addresses in the IP ranges of the DHCP server
+	# TYPE fortigate_dhcp_pool_size gauge
+	fortigate_dhcp_pool_size{interface="guest",server_id="2",vdom="root"} 20
+	fortigate_dhcp_pool_size{interface="internal",server_id="1",vdom="branch"} 253
+	fortigate_dhcp_pool_size{interface="port2",server_id="1",vdom="root"} 101
+	# HELP fortigate_dhcp_pool_utilization_ratio Ratio of active leases to addresses in the IP ranges of the DHCP server (0 - 1.0)
+	# TYPE fortigate_dhcp_pool_utilization_ratio gauge
+	fortigate_dhcp_pool_utilization_ratio{interface="guest",server_id="2",vdom="root"} 0.1
+	fortigate_dhcp_pool_utilization_ratio{interface="internal",server_id="1",vdom="branch"} 0
+	fortigate_dhcp_pool_utilization_ratio{interface="port2",server_id="1",vdom="root"} 0.0297029702970297
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
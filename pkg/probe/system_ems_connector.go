@@ -0,0 +1,76 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type EMSConnectorStatus struct {
+	Name                string  `json:"name"`
+	Serial              string  `json:"serial"`
+	Status              string  `json:"status"`
+	LastSync            float64 `json:"last_sync"`
+	RegisteredEndpoints float64 `json:"registered_endpoints"`
+}
+
+type EMSConnectorStatusResponse struct {
+	Results []EMSConnectorStatus `json:"results"`
+	VDOM    string               `json:"vdom"`
+}
+
+func probeSystemEMSConnector(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mUp = prometheus.NewDesc(
+			"fortigate_system_ems_connector_up",
+			"Whether the FortiClient EMS connector is connected",
+			[]string{"vdom", "name", "serial"}, nil,
+		)
+		mLastSync = prometheus.NewDesc(
+			"fortigate_system_ems_connector_last_sync_seconds",
+			"Last time endpoint information was synchronized from FortiClient EMS (in seconds from epoch)",
+			[]string{"vdom", "name", "serial"}, nil,
+		)
+		mEndpoints = prometheus.NewDesc(
+			"fortigate_system_ems_connector_registered_endpoints",
+			"Number of endpoints registered with FortiClient EMS",
+			[]string{"vdom", "name", "serial"}, nil,
+		)
+	)
+
+	var res []EMSConnectorStatusResponse
+	if err := c.Get("api/v2/monitor/endpoint-control/ems/status-summary", "vdom=*", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range res {
+		for _, ems := range r.Results {
+			up := 0.0
+			if ems.Status == "connected" {
+				up = 1
+			}
+			m = append(m, prometheus.MustNewConstMetric(mUp, prometheus.GaugeValue, up, r.VDOM, ems.Name, ems.Serial))
+			m = append(m, prometheus.MustNewConstMetric(mLastSync, prometheus.GaugeValue, ems.LastSync, r.VDOM, ems.Name, ems.Serial))
+			m = append(m, prometheus.MustNewConstMetric(mEndpoints, prometheus.GaugeValue, ems.RegisteredEndpoints, r.VDOM, ems.Name, ems.Serial))
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,50 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemEMSConnector(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/endpoint-control/ems/status-summary", "testdata/endpoint-control-ems-status-summary.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemEMSConnector, c, r) {
+		t.Errorf("probeSystemEMSConnector() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_system_ems_connector_last_sync_seconds Last time endpoint information was synchronized from FortiClient EMS (in seconds from epoch)
+	# TYPE fortigate_system_ems_connector_last_sync_seconds gauge
+	fortigate_system_ems_connector_last_sync_seconds{name="ems-lab",serial="FCTEMS8822000002",vdom="root"} 1.699012345e+09
+	fortigate_system_ems_connector_last_sync_seconds{name="ems-prod",serial="FCTEMS8822000001",vdom="root"} 1.69999994e+09
+	# HELP fortigate_system_ems_connector_registered_endpoints Number of endpoints registered with FortiClient EMS
+	# TYPE fortigate_system_ems_connector_registered_endpoints gauge
+	fortigate_system_ems_connector_registered_endpoints{name="ems-lab",serial="FCTEMS8822000002",vdom="root"} 12
+	fortigate_system_ems_connector_registered_endpoints{name="ems-prod",serial="FCTEMS8822000001",vdom="root"} 1532
+	# HELP fortigate_system_ems_connector_up Whether the FortiClient EMS connector is connected
+	# TYPE fortigate_system_ems_connector_up gauge
+	fortigate_system_ems_connector_up{name="ems-lab",serial="FCTEMS8822000002",vdom="root"} 0
+	fortigate_system_ems_connector_up{name="ems-prod",serial="FCTEMS8822000001",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
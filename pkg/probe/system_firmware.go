@@ -0,0 +1,124 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/version"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type FirmwareImage struct {
+	Version    string `json:"version"`
+	Build      int64  `json:"build"`
+	PlatformID string `json:"platform-id"`
+	Maturity   string `json:"maturity"`
+}
+
+type FirmwareResponse struct {
+	Results struct {
+		Current   FirmwareImage   `json:"current"`
+		Available []FirmwareImage `json:"available"`
+	} `json:"results"`
+}
+
+// newSystemFirmwareProbe returns a probe reporting the running and available
+// firmware, compared against minimumVersion if it is set.
+func newSystemFirmwareProbe(minimumVersion string) probeFunc {
+	return func(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+		return probeSystemFirmware(c, minimumVersion)
+	}
+}
+
+func probeSystemFirmware(c http.FortiHTTP, minimumVersion string) ([]prometheus.Metric, bool) {
+	var (
+		mCurrent = prometheus.NewDesc(
+			"fortigate_firmware_info",
+			"Running firmware image",
+			[]string{"platform", "version", "build"}, nil,
+		)
+		mAvailable = prometheus.NewDesc(
+			"fortigate_firmware_available_info",
+			"Firmware image newer than the running one available from FortiGuard",
+			[]string{"version", "build", "maturity"}, nil,
+		)
+		mUpgrade = prometheus.NewDesc(
+			"fortigate_firmware_upgrade_available",
+			"Whether a firmware image newer than the running one is available from FortiGuard",
+			nil, nil,
+		)
+		mBelowBaseline = prometheus.NewDesc(
+			"fortigate_firmware_below_baseline",
+			"Whether the running firmware is older than the configured minimum version",
+			[]string{"minimum_version"}, nil,
+		)
+	)
+
+	var res FirmwareResponse
+	if err := c.Get("api/v2/monitor/system/firmware", "", &res); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	cur := res.Results.Current
+	current, ok := version.ParseFullVersion(cur.Version)
+	if !ok {
+		log.Printf("Error: Failed to parse firmware version: %q", cur.Version)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{
+		prometheus.MustNewConstMetric(mCurrent, prometheus.GaugeValue, 1, cur.PlatformID, cur.Version, strconv.FormatInt(cur.Build, 10)),
+	}
+
+	// FortiGuard can list the same image more than once, e.g. once per
+	// release channel, which is reported only once
+	type image struct {
+		version  string
+		build    int64
+		maturity string
+	}
+	seen := map[image]bool{}
+	upgrade := 0.0
+	for _, a := range res.Results.Available {
+		v, ok := version.ParseFullVersion(a.Version)
+		if !ok || slices.Compare(v[:], current[:]) <= 0 {
+			continue
+		}
+		upgrade = 1
+		k := image{a.Version, a.Build, a.Maturity}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		m = append(m, prometheus.MustNewConstMetric(mAvailable, prometheus.GaugeValue, 1, a.Version, strconv.FormatInt(a.Build, 10), a.Maturity))
+	}
+	m = append(m, prometheus.MustNewConstMetric(mUpgrade, prometheus.GaugeValue, upgrade))
+
+	// The minimum version is validated when loading the configuration
+	if minimum, ok := version.ParseFullVersion(minimumVersion); ok {
+		below := 0.0
+		if slices.Compare(current[:], minimum[:]) < 0 {
+			below = 1
+		}
+		m = append(m, prometheus.MustNewConstMetric(mBelowBaseline, prometheus.GaugeValue, below, minimumVersion))
+	}
+
+	return m, true
+}
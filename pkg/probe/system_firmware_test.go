@@ -0,0 +1,84 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSystemFirmware(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/firmware", "testdata/system-firmware.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSystemFirmwareProbe("7.2.7"), c, r) {
+		t.Errorf("probeSystemFirmware() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firmware_available_info Firmware image newer than the running one available from FortiGuard
+	# TYPE fortigate_firmware_available_info gauge
+	fortigate_firmware_available_info{build="1639",maturity="M",version="v7.2.8"} 1
+	fortigate_firmware_available_info{build="2573",maturity="F",version="v7.4.3"} 1
+	# HELP fortigate_firmware_below_baseline Whether the running firmware is older than the configured minimum version
+	# TYPE fortigate_firmware_below_baseline gauge
+	fortigate_firmware_below_baseline{minimum_version="7.2.7"} 1
+	# HELP fortigate_firmware_info Running firmware image
+	# TYPE fortigate_firmware_info gauge
+	fortigate_firmware_info{build="1517",platform="FGT61F",version="v7.2.5"} 1
+	# HELP fortigate_firmware_upgrade_available Whether a firmware image newer than the running one is available from FortiGuard
+	# TYPE fortigate_firmware_upgrade_available gauge
+	fortigate_firmware_upgrade_available 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSystemFirmwareNoBaseline(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/firmware", "testdata/system-firmware.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSystemFirmwareProbe(""), c, r) {
+		t.Errorf("probeSystemFirmware() returned non-success")
+	}
+
+	if n := testutil.CollectAndCount(r, "fortigate_firmware_below_baseline"); n != 0 {
+		t.Errorf("expected no baseline metric without minimum version, got %d", n)
+	}
+}
+
+func TestSystemFirmwareDuplicate(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/firmware", "testdata/system-firmware-duplicate.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(newSystemFirmwareProbe(""), c, r) {
+		t.Errorf("probeSystemFirmware() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_firmware_available_info Firmware image newer than the running one available from FortiGuard
+	# TYPE fortigate_firmware_available_info gauge
+	fortigate_firmware_available_info{build="1639",maturity="M",version="v7.2.8"} 1
+	fortigate_firmware_available_info{build="2573",maturity="F",version="v7.4.3"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_firmware_available_info"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type NPUSessionStats struct {
+	ID           int                `json:"id"`
+	Model        string             `json:"model"`
+	SessionCount float64            `json:"session_count"`
+	SessionMax   float64            `json:"session_max"`
+	Drops        map[string]float64 `json:"drops"`
+}
+
+type NPUSessionStatsResponse struct {
+	Results []NPUSessionStats `json:"results"`
+}
+
+func probeSystemNPU(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mInfo = prometheus.NewDesc(
+			"fortigate_npu_info",
+			"Info metric containing the model of a network processor",
+			[]string{"npu", "model"}, nil,
+		)
+		mSessions = prometheus.NewDesc(
+			"fortigate_npu_sessions",
+			"Number of sessions offloaded to a network processor",
+			[]string{"npu"}, nil,
+		)
+		mMaxSessions = prometheus.NewDesc(
+			"fortigate_npu_max_sessions",
+			"Maximum number of sessions a network processor can offload",
+			[]string{"npu"}, nil,
+		)
+		mUsage = prometheus.NewDesc(
+			"fortigate_npu_session_usage_ratio",
+			"Ratio of the session table of a network processor in use",
+			[]string{"npu"}, nil,
+		)
+		mDrops = prometheus.NewDesc(
+			"fortigate_npu_dropped_packets_total",
+			"Number of packets dropped by a network processor per reason",
+			[]string{"npu", "reason"}, nil,
+		)
+	)
+
+	// Models without network processors, like VMs, do not have the endpoint
+	// or report no processors
+	var res NPUSessionStatsResponse
+	if err := c.Get("api/v2/monitor/system/npu/session-stats", "", &res); err != nil {
+		if http.IsNotFound(err) {
+			return nil, true
+		}
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if len(res.Results) == 0 {
+		return nil, true
+	}
+
+	m := []prometheus.Metric{}
+	for _, np := range res.Results {
+		npu := strconv.Itoa(np.ID)
+		m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, 1, npu, np.Model))
+		m = append(m, prometheus.MustNewConstMetric(mSessions, prometheus.GaugeValue, np.SessionCount, npu))
+		m = append(m, prometheus.MustNewConstMetric(mMaxSessions, prometheus.GaugeValue, np.SessionMax, npu))
+		if np.SessionMax > 0 {
+			m = append(m, prometheus.MustNewConstMetric(mUsage, prometheus.GaugeValue, np.SessionCount/np.SessionMax, npu))
+		}
+		for reason, drops := range np.Drops {
+			m = append(m, prometheus.MustNewConstMetric(mDrops, prometheus.CounterValue, drops, npu, reason))
+		}
+	}
+
+	return m, true
+}
@@ -0,0 +1,86 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+func TestSystemNPU(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/npu/session-stats", "testdata/system-npu-session-stats.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeSystemNPU, c, r) {
+		t.Errorf("probeSystemNPU() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_npu_dropped_packets_total Number of packets dropped by a network processor per reason
+	# TYPE fortigate_npu_dropped_packets_total counter
+	fortigate_npu_dropped_packets_total{npu="0",reason="checksum_error"} 3
+	fortigate_npu_dropped_packets_total{npu="0",reason="ip_fragment"} 12
+	fortigate_npu_dropped_packets_total{npu="0",reason="queue_full"} 1024
+	fortigate_npu_dropped_packets_total{npu="1",reason="checksum_error"} 0
+	fortigate_npu_dropped_packets_total{npu="1",reason="ip_fragment"} 0
+	fortigate_npu_dropped_packets_total{npu="1",reason="queue_full"} 0
+	# HELP fortigate_npu_info Info metric containing the model of a network processor
+	# TYPE fortigate_npu_info gauge
+	fortigate_npu_info{model="NP7",npu="0"} 1
+	fortigate_npu_info{model="NP7",npu="1"} 1
+	# HELP fortigate_npu_max_sessions Maximum number of sessions a network processor can offload
+	# TYPE fortigate_npu_max_sessions gauge
+	fortigate_npu_max_sessions{npu="0"} 1e+06
+	fortigate_npu_max_sessions{npu="1"} 1e+06
+	# HELP fortigate_npu_session_usage_ratio Ratio of the session table of a network processor in use
+	# TYPE fortigate_npu_session_usage_ratio gauge
+	fortigate_npu_session_usage_ratio{npu="0"} 0.25
+	fortigate_npu_session_usage_ratio{npu="1"} 0
+	# HELP fortigate_npu_sessions Number of sessions offloaded to a network processor
+	# TYPE fortigate_npu_sessions gauge
+	fortigate_npu_sessions{npu="0"} 250000
+	fortigate_npu_sessions{npu="1"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestSystemNPUNotSupported(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/system/npu/session-stats", "testdata/system-npu-session-stats-none.jsonnet")
+	m, ok := probeSystemNPU(c, &TargetMetadata{VersionMajor: 7, VersionMinor: 2})
+	if !ok || len(m) != 0 {
+		t.Errorf("probeSystemNPU() returned %d metrics, %v, expected none and success without network processors", len(m), ok)
+	}
+
+	c = newFakeClient()
+	c.prepareError("api/v2/monitor/system/npu/session-stats", &http.StatusError{Code: 404, Path: "api/v2/monitor/system/npu/session-stats"})
+	m, ok = probeSystemNPU(c, &TargetMetadata{VersionMajor: 7, VersionMinor: 2})
+	if !ok || len(m) != 0 {
+		t.Errorf("probeSystemNPU() returned %d metrics, %v, expected none and success on 404", len(m), ok)
+	}
+
+	c = newFakeClient()
+	c.prepareError("api/v2/monitor/system/npu/session-stats", &http.StatusError{Code: 403, Path: "api/v2/monitor/system/npu/session-stats"})
+	if _, ok := probeSystemNPU(c, &TargetMetadata{VersionMajor: 7, VersionMinor: 2}); ok {
+		t.Errorf("probeSystemNPU() returned success on 403")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type FirewallUser struct {
+	Method    string `json:"method"`
+	UserGroup []struct {
+		Name string `json:"name"`
+	} `json:"usergroup"`
+}
+
+type FortiToken struct {
+	Serial string `json:"serial"`
+	Status string `json:"status"`
+}
+
+type FortiTokenResponse struct {
+	Results []FortiToken `json:"results"`
+	VDOM    string       `json:"vdom"`
+}
+
+// fortiTokenStatuses are always reported, other statuses only if they are seen
+var fortiTokenStatuses = []string{"assigned", "available", "locked", "expired"}
+
+type userCount struct {
+	VDOM  string
+	Value string
+}
+
+// firewallUserCounter counts the authenticated users per method and group
+// while they are decoded.
+type firewallUserCounter struct {
+	methods map[userCount]int
+	groups  map[userCount]int
+	// counts of the VDOM currently decoded, keyed by method or group
+	curMethods map[string]int
+	curGroups  map[string]int
+}
+
+func newFirewallUserCounter() *firewallUserCounter {
+	return &firewallUserCounter{
+		methods:    make(map[userCount]int),
+		groups:     make(map[userCount]int),
+		curMethods: make(map[string]int),
+		curGroups:  make(map[string]int),
+	}
+}
+
+func (uc *firewallUserCounter) Entry(u FirewallUser) {
+	uc.curMethods[u.Method]++
+	for _, g := range u.UserGroup {
+		uc.curGroups[g.Name]++
+	}
+}
+
+func (uc *firewallUserCounter) EndVDOM(vdom string) {
+	for method, count := range uc.curMethods {
+		uc.methods[userCount{VDOM: vdom, Value: method}] += count
+	}
+	for group, count := range uc.curGroups {
+		uc.groups[userCount{VDOM: vdom, Value: group}] += count
+	}
+	clear(uc.curMethods)
+	clear(uc.curGroups)
+}
+
+func probeUserFirewall(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mUsers = prometheus.NewDesc(
+			"fortigate_user_firewall_users",
+			"Number of authenticated firewall users by authentication method",
+			[]string{"vdom", "method"}, nil,
+		)
+		mGroupUsers = prometheus.NewDesc(
+			"fortigate_user_firewall_group_users",
+			"Number of authenticated firewall users by user group, users in several groups are counted in each",
+			[]string{"vdom", "group"}, nil,
+		)
+		mTokens = prometheus.NewDesc(
+			"fortigate_user_fortitokens",
+			"Number of FortiTokens by status",
+			[]string{"vdom", "status"}, nil,
+		)
+	)
+
+	maxEntries := config.GetConfig().MaxListEntries
+	users := newFirewallUserCounter()
+	truncated, err := http.VisitAll[FirewallUser](c, "api/v2/monitor/user/firewall", "vdom=*", maxEntries, users)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more firewall users than maximum (%d) allowed, counts are incomplete", maxEntries)
+	}
+
+	var tokens []FortiTokenResponse
+	if err := c.Get("api/v2/monitor/user/fortitoken", "vdom=*", &tokens); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	tokenCounts := map[userCount]int{}
+	for _, r := range tokens {
+		for _, status := range fortiTokenStatuses {
+			tokenCounts[userCount{VDOM: r.VDOM, Value: status}] = 0
+		}
+		for _, t := range r.Results {
+			tokenCounts[userCount{VDOM: r.VDOM, Value: t.Status}]++
+		}
+	}
+
+	m := []prometheus.Metric{probeTruncated("User/Firewall", truncated)}
+	for k, count := range users.methods {
+		m = append(m, prometheus.MustNewConstMetric(mUsers, prometheus.GaugeValue, float64(count), k.VDOM, k.Value))
+	}
+	for k, count := range users.groups {
+		m = append(m, prometheus.MustNewConstMetric(mGroupUsers, prometheus.GaugeValue, float64(count), k.VDOM, k.Value))
+	}
+	for k, count := range tokenCounts {
+		m = append(m, prometheus.MustNewConstMetric(mTokens, prometheus.GaugeValue, float64(count), k.VDOM, k.Value))
+	}
+
+	return m, true
+}
@@ -0,0 +1,63 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestUserFirewall(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/user/firewall", "testdata/user-firewall.jsonnet")
+	c.prepare("api/v2/monitor/user/fortitoken", "testdata/user-fortitoken.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeUserFirewall, c, r) {
+		t.Errorf("probeUserFirewall() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="User/Firewall"} 0
+	# HELP fortigate_user_firewall_group_users Number of authenticated firewall users by user group, users in several groups are counted in each
+	# TYPE fortigate_user_firewall_group_users gauge
+	fortigate_user_firewall_group_users{group="admins",vdom="root"} 1
+	fortigate_user_firewall_group_users{group="staff",vdom="root"} 3
+	# HELP fortigate_user_firewall_users Number of authenticated firewall users by authentication method
+	# TYPE fortigate_user_firewall_users gauge
+	fortigate_user_firewall_users{method="firewall",vdom="root"} 2
+	fortigate_user_firewall_users{method="fsso",vdom="root"} 1
+	fortigate_user_firewall_users{method="rsso",vdom="root"} 1
+	# HELP fortigate_user_fortitokens Number of FortiTokens by status
+	# TYPE fortigate_user_fortitokens gauge
+	fortigate_user_fortitokens{status="assigned",vdom="root"} 2
+	fortigate_user_fortitokens{status="available",vdom="root"} 1
+	fortigate_user_fortitokens{status="expired",vdom="root"} 1
+	fortigate_user_fortitokens{status="locked",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
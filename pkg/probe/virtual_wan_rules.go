@@ -0,0 +1,174 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type VirtualWANRuleMember struct {
+	SeqNum    int     `json:"seq_num"`
+	Interface string  `json:"interface"`
+	Status    string  `json:"status"`
+	SLAMatch  bool    `json:"sla_match"`
+	Priority  float64 `json:"priority"`
+	Cost      float64 `json:"cost"`
+}
+
+type VirtualWANRule struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Mode string `json:"mode"`
+	// Members are ordered by preference
+	Members []VirtualWANRuleMember `json:"members"`
+}
+
+// virtualWANSelectingModes lists the modes of SD-WAN rules which steer all
+// traffic to the first alive member. Other modes select members by SLA,
+// link quality or balance the traffic, which the API does not tell, so no
+// member is reported as selected for them.
+var virtualWANSelectingModes = map[string]bool{
+	"manual":   true,
+	"priority": true,
+}
+
+type VirtualWANRulesResponse struct {
+	Results []VirtualWANRule `json:"results"`
+	VDOM    string           `json:"vdom"`
+}
+
+type VirtualWANMember struct {
+	Interface   string  `json:"interface"`
+	Link        string  `json:"link"`
+	Session     float64 `json:"session"`
+	TxBandwidth float64 `json:"tx_bandwidth"`
+	RxBandwidth float64 `json:"rx_bandwidth"`
+}
+
+type VirtualWANMembersResponse struct {
+	Results map[string]VirtualWANMember `json:"results"`
+	VDOM    string                      `json:"vdom"`
+}
+
+func probeVirtualWANRules(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mSelected = prometheus.NewDesc(
+			"fortigate_virtual_wan_rule_member_selected",
+			"Whether the SD-WAN rule currently steers traffic to the member, only reported for rules in manual and priority mode (0 - No, 1 - Yes)",
+			[]string{"vdom", "rule_id", "rule", "mode", "interface"}, nil,
+		)
+		mAlive = prometheus.NewDesc(
+			"fortigate_virtual_wan_rule_member_alive",
+			"Whether the member of the SD-WAN rule is alive (0 - Dead, 1 - Alive)",
+			[]string{"vdom", "rule_id", "rule", "interface"}, nil,
+		)
+		mSLAMet = prometheus.NewDesc(
+			"fortigate_virtual_wan_rule_member_sla_target_met",
+			"Whether the member meets the SLA targets of the SD-WAN rule (0 - No, 1 - Yes)",
+			[]string{"vdom", "rule_id", "rule", "interface"}, nil,
+		)
+		mPriority = prometheus.NewDesc(
+			"fortigate_virtual_wan_rule_member_priority",
+			"Priority of the member in the SD-WAN rule",
+			[]string{"vdom", "rule_id", "rule", "interface"}, nil,
+		)
+		mCost = prometheus.NewDesc(
+			"fortigate_virtual_wan_rule_member_cost",
+			"Cost of the member in the SD-WAN rule",
+			[]string{"vdom", "rule_id", "rule", "interface"}, nil,
+		)
+		mMemberLink = prometheus.NewDesc(
+			"fortigate_virtual_wan_member_up",
+			"Link status of the SD-WAN member (0 - Down, 1 - Up)",
+			[]string{"vdom", "interface"}, nil,
+		)
+		mMemberSessions = prometheus.NewDesc(
+			"fortigate_virtual_wan_member_active_sessions",
+			"Active session count of the SD-WAN member",
+			[]string{"vdom", "interface"}, nil,
+		)
+		mMemberTX = prometheus.NewDesc(
+			"fortigate_virtual_wan_member_bandwidth_tx_byte_per_second",
+			"Upload bandwidth of the SD-WAN member",
+			[]string{"vdom", "interface"}, nil,
+		)
+		mMemberRX = prometheus.NewDesc(
+			"fortigate_virtual_wan_member_bandwidth_rx_byte_per_second",
+			"Download bandwidth of the SD-WAN member",
+			[]string{"vdom", "interface"}, nil,
+		)
+	)
+
+	var rules []VirtualWANRulesResponse
+	if err := c.Get("api/v2/monitor/virtual-wan/service", "vdom=*", &rules); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	var members []VirtualWANMembersResponse
+	if err := c.Get("api/v2/monitor/virtual-wan/members", "vdom=*", &members); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	m := []prometheus.Metric{}
+	for _, r := range rules {
+		for _, rule := range r.Results {
+			id := strconv.Itoa(rule.ID)
+			selecting := virtualWANSelectingModes[rule.Mode]
+			selected := false
+			for _, member := range rule.Members {
+				alive, sel := 0.0, 0.0
+				if member.Status == "alive" {
+					alive = 1.0
+					if !selected {
+						selected = true
+						sel = 1.0
+					}
+				}
+				slaMet := 0.0
+				if member.SLAMatch {
+					slaMet = 1.0
+				}
+				if selecting {
+					m = append(m, prometheus.MustNewConstMetric(mSelected, prometheus.GaugeValue, sel, r.VDOM, id, rule.Name, rule.Mode, member.Interface))
+				}
+				m = append(m, prometheus.MustNewConstMetric(mAlive, prometheus.GaugeValue, alive, r.VDOM, id, rule.Name, member.Interface))
+				m = append(m, prometheus.MustNewConstMetric(mSLAMet, prometheus.GaugeValue, slaMet, r.VDOM, id, rule.Name, member.Interface))
+				m = append(m, prometheus.MustNewConstMetric(mPriority, prometheus.GaugeValue, member.Priority, r.VDOM, id, rule.Name, member.Interface))
+				m = append(m, prometheus.MustNewConstMetric(mCost, prometheus.GaugeValue, member.Cost, r.VDOM, id, rule.Name, member.Interface))
+			}
+		}
+	}
+
+	for _, r := range members {
+		for name, member := range r.Results {
+			up := 0.0
+			if member.Link == "up" {
+				up = 1.0
+			}
+			m = append(m, prometheus.MustNewConstMetric(mMemberLink, prometheus.GaugeValue, up, r.VDOM, name))
+			m = append(m, prometheus.MustNewConstMetric(mMemberSessions, prometheus.GaugeValue, member.Session, r.VDOM, name))
+			m = append(m, prometheus.MustNewConstMetric(mMemberTX, prometheus.GaugeValue, member.TxBandwidth/8, r.VDOM, name))
+			m = append(m, prometheus.MustNewConstMetric(mMemberRX, prometheus.GaugeValue, member.RxBandwidth/8, r.VDOM, name))
+		}
+	}
+
+	return m, true
+}
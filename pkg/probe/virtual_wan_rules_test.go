@@ -0,0 +1,91 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestVirtualWANRules(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/virtual-wan/service", "testdata/virtual-wan-service.jsonnet")
+	c.prepare("api/v2/monitor/virtual-wan/members", "testdata/virtual-wan-members.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeVirtualWANRules, c, r) {
+		t.Errorf("probeVirtualWANRules() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_virtual_wan_member_active_sessions Active session count of the SD-WAN member
+	# TYPE fortigate_virtual_wan_member_active_sessions gauge
+	fortigate_virtual_wan_member_active_sessions{interface="lte",vdom="root"} 15
+	fortigate_virtual_wan_member_active_sessions{interface="wan1",vdom="root"} 0
+	fortigate_virtual_wan_member_active_sessions{interface="wan2",vdom="root"} 321
+	# HELP fortigate_virtual_wan_member_bandwidth_rx_byte_per_second Download bandwidth of the SD-WAN member
+	# TYPE fortigate_virtual_wan_member_bandwidth_rx_byte_per_second gauge
+	fortigate_virtual_wan_member_bandwidth_rx_byte_per_second{interface="lte",vdom="root"} 2000
+	fortigate_virtual_wan_member_bandwidth_rx_byte_per_second{interface="wan1",vdom="root"} 0
+	fortigate_virtual_wan_member_bandwidth_rx_byte_per_second{interface="wan2",vdom="root"} 20000
+	# HELP fortigate_virtual_wan_member_bandwidth_tx_byte_per_second Upload bandwidth of the SD-WAN member
+	# TYPE fortigate_virtual_wan_member_bandwidth_tx_byte_per_second gauge
+	fortigate_virtual_wan_member_bandwidth_tx_byte_per_second{interface="lte",vdom="root"} 1000
+	fortigate_virtual_wan_member_bandwidth_tx_byte_per_second{interface="wan1",vdom="root"} 0
+	fortigate_virtual_wan_member_bandwidth_tx_byte_per_second{interface="wan2",vdom="root"} 10000
+	# HELP fortigate_virtual_wan_member_up Link status of the SD-WAN member (0 - Down, 1 - Up)
+	# TYPE fortigate_virtual_wan_member_up gauge
+	fortigate_virtual_wan_member_up{interface="lte",vdom="root"} 1
+	fortigate_virtual_wan_member_up{interface="wan1",vdom="root"} 0
+	fortigate_virtual_wan_member_up{interface="wan2",vdom="root"} 1
+	# HELP fortigate_virtual_wan_rule_member_alive Whether the member of the SD-WAN rule is alive (0 - Dead, 1 - Alive)
+	# TYPE fortigate_virtual_wan_rule_member_alive gauge
+	fortigate_virtual_wan_rule_member_alive{interface="lte",rule="critical-apps",rule_id="1",vdom="root"} 1
+	fortigate_virtual_wan_rule_member_alive{interface="wan1",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_alive{interface="wan1",rule="critical-apps",rule_id="1",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_alive{interface="wan2",rule="bulk",rule_id="2",vdom="root"} 1
+	fortigate_virtual_wan_rule_member_alive{interface="wan2",rule="critical-apps",rule_id="1",vdom="root"} 1
+	# HELP fortigate_virtual_wan_rule_member_cost Cost of the member in the SD-WAN rule
+	# TYPE fortigate_virtual_wan_rule_member_cost gauge
+	fortigate_virtual_wan_rule_member_cost{interface="lte",rule="critical-apps",rule_id="1",vdom="root"} 10
+	fortigate_virtual_wan_rule_member_cost{interface="wan1",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_cost{interface="wan1",rule="critical-apps",rule_id="1",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_cost{interface="wan2",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_cost{interface="wan2",rule="critical-apps",rule_id="1",vdom="root"} 5
+	# HELP fortigate_virtual_wan_rule_member_priority Priority of the member in the SD-WAN rule
+	# TYPE fortigate_virtual_wan_rule_member_priority gauge
+	fortigate_virtual_wan_rule_member_priority{interface="lte",rule="critical-apps",rule_id="1",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_priority{interface="wan1",rule="bulk",rule_id="2",vdom="root"} 20
+	fortigate_virtual_wan_rule_member_priority{interface="wan1",rule="critical-apps",rule_id="1",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_priority{interface="wan2",rule="bulk",rule_id="2",vdom="root"} 10
+	fortigate_virtual_wan_rule_member_priority{interface="wan2",rule="critical-apps",rule_id="1",vdom="root"} 0
+	# HELP fortigate_virtual_wan_rule_member_selected Whether the SD-WAN rule currently steers traffic to the member, only reported for rules in manual and priority mode (0 - No, 1 - Yes)
+	# TYPE fortigate_virtual_wan_rule_member_selected gauge
+	fortigate_virtual_wan_rule_member_selected{interface="wan1",mode="priority",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_selected{interface="wan2",mode="priority",rule="bulk",rule_id="2",vdom="root"} 1
+	# HELP fortigate_virtual_wan_rule_member_sla_target_met Whether the member meets the SLA targets of the SD-WAN rule (0 - No, 1 - Yes)
+	# TYPE fortigate_virtual_wan_rule_member_sla_target_met gauge
+	fortigate_virtual_wan_rule_member_sla_target_met{interface="lte",rule="critical-apps",rule_id="1",vdom="root"} 1
+	fortigate_virtual_wan_rule_member_sla_target_met{interface="wan1",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_sla_target_met{interface="wan1",rule="critical-apps",rule_id="1",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_sla_target_met{interface="wan2",rule="bulk",rule_id="2",vdom="root"} 0
+	fortigate_virtual_wan_rule_member_sla_target_met{interface="wan2",rule="critical-apps",rule_id="1",vdom="root"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
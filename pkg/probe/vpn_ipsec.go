@@ -39,6 +39,36 @@ func probeVPNIPSec(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bo
 			"Total number of bytes received over the IPsec tunnel",
 			[]string{"vdom", "name", "p2serial", "parent"}, nil,
 		)
+		p1Info = prometheus.NewDesc(
+			"fortigate_ipsec_phase1_info",
+			"Configuration of IPsec phase-1 tunnels, dpd_mode is the configured dead peer detection mode",
+			[]string{"vdom", "name", "remote_gateway", "ike_version", "dpd_mode"}, nil,
+		)
+		p1Status = prometheus.NewDesc(
+			"fortigate_ipsec_phase1_up",
+			"Status of IPsec phase-1 tunnel, up if at least one phase-2 is up (0 - Down, 1 - Up)",
+			[]string{"vdom", "name"}, nil,
+		)
+		p1Uptime = prometheus.NewDesc(
+			"fortigate_ipsec_phase1_uptime_seconds",
+			"Seconds since the IPsec phase-1 tunnel has been created",
+			[]string{"vdom", "name"}, nil,
+		)
+		dialupClients = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_clients",
+			"Number of clients connected to the IPsec dial-up tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
+		dialupTransmitted = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_transmit_bytes",
+			"Number of bytes transmitted to the currently connected clients of the IPsec dial-up tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
+		dialupReceived = prometheus.NewDesc(
+			"fortigate_ipsec_dialup_receive_bytes",
+			"Number of bytes received from the currently connected clients of the IPsec dial-up tunnel",
+			[]string{"vdom", "parent"}, nil,
+		)
 	)
 
 	type proxyid struct {
@@ -49,40 +79,109 @@ func probeVPNIPSec(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bo
 		Outgoing float64 `json:"outgoing_bytes"`
 	}
 	type tunnel struct {
-		Name    string    `json:"name"`
-		Type    string    `json:"type"`
-		ProxyID []proxyid `json:"proxyid"`
+		Name         string    `json:"name"`
+		Type         string    `json:"type"`
+		Parent       string    `json:"parent"`
+		RemoteGW     string    `json:"rgwy"`
+		CreationTime float64   `json:"creation_time"`
+		Incoming     float64   `json:"incoming_bytes"`
+		Outgoing     float64   `json:"outgoing_bytes"`
+		ProxyID      []proxyid `json:"proxyid"`
 	}
 	type ipsecResult struct {
 		Results []tunnel `json:"results"`
 		VDOM    string
 	}
+	type phase1 struct {
+		Name       string `json:"name"`
+		Type       string `json:"type"`
+		IKEVersion string `json:"ike-version"`
+		DPD        string `json:"dpd"`
+	}
+	type phase1Result struct {
+		Results []phase1 `json:"results"`
+		VDOM    string   `json:"vdom"`
+	}
 	var res []ipsecResult
 	if err := c.Get("api/v2/monitor/vpn/ipsec", "vdom=*", &res); err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
+	// The configuration only adds labels, without access to it the tunnels
+	// are still reported with empty ike_version and dpd_mode
+	var p1res []phase1Result
+	if err := c.Get("api/v2/cmdb/vpn.ipsec/phase1-interface", "vdom=*&format=name|type|ike-version|dpd", &p1res); err != nil {
+		log.Printf("Warning: Unable to fetch IPsec phase-1 configuration: %v", err)
+		p1res = nil
+	}
+
+	type dialupStats struct {
+		clients  float64
+		incoming float64
+		outgoing float64
+	}
+	type tunnelKey struct {
+		vdom string
+		name string
+	}
+	p1Config := map[tunnelKey]phase1{}
+	dialups := map[tunnelKey]*dialupStats{}
+	for _, v := range p1res {
+		for _, p1 := range v.Results {
+			p1Config[tunnelKey{v.VDOM, p1.Name}] = p1
+			if p1.Type == "dynamic" {
+				dialups[tunnelKey{v.VDOM, p1.Name}] = &dialupStats{}
+			}
+		}
+	}
 
 	m := []prometheus.Metric{}
 	for _, v := range res {
 		for _, i := range v.Results {
 			/*
-			  type 'dialup' seems to be client vpn.
-			  Not sure exactly what the difference is between probeVPNSsl
+			  type 'dialup' are the tunnels of the dial-up clients, which come and go
+			  and are only counted per parent tunnel to keep the cardinality low.
 			*/
 			if i.Type == "dialup" {
+				if i.Parent == "" {
+					// The dial-up tunnel itself, connected clients are listed with it as parent
+					if _, ok := dialups[tunnelKey{v.VDOM, i.Name}]; !ok {
+						dialups[tunnelKey{v.VDOM, i.Name}] = &dialupStats{}
+					}
+					continue
+				}
+				d, ok := dialups[tunnelKey{v.VDOM, i.Parent}]
+				if !ok {
+					d = &dialupStats{}
+					dialups[tunnelKey{v.VDOM, i.Parent}] = d
+				}
+				d.clients++
+				d.incoming += i.Incoming
+				d.outgoing += i.Outgoing
 				continue
 			}
+
+			p1 := p1Config[tunnelKey{v.VDOM, i.Name}]
+			p1Up := 0.0
+			m = append(m, prometheus.MustNewConstMetric(p1Info, prometheus.GaugeValue, 1, v.VDOM, i.Name, i.RemoteGW, p1.IKEVersion, p1.DPD))
+			m = append(m, prometheus.MustNewConstMetric(p1Uptime, prometheus.GaugeValue, i.CreationTime, v.VDOM, i.Name))
 			for _, t := range i.ProxyID {
 				s := 0.0
 				if t.Status == "up" {
 					s = 1.0
+					p1Up = 1.0
 				}
 				m = append(m, prometheus.MustNewConstMetric(status, prometheus.GaugeValue, s, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
 				m = append(m, prometheus.MustNewConstMetric(transmitted, prometheus.CounterValue, t.Outgoing, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
 				m = append(m, prometheus.MustNewConstMetric(received, prometheus.CounterValue, t.Incoming, v.VDOM, t.Name, strconv.Itoa(t.P2serial), i.Name))
 			}
+			m = append(m, prometheus.MustNewConstMetric(p1Status, prometheus.GaugeValue, p1Up, v.VDOM, i.Name))
 		}
 	}
+	for k, d := range dialups {
+		m = append(m, prometheus.MustNewConstMetric(dialupClients, prometheus.GaugeValue, d.clients, k.vdom, k.name))
+		m = append(m, prometheus.MustNewConstMetric(dialupTransmitted, prometheus.GaugeValue, d.outgoing, k.vdom, k.name))
+		m = append(m, prometheus.MustNewConstMetric(dialupReceived, prometheus.GaugeValue, d.incoming, k.vdom, k.name))
+	}
 	return m, true
 }
@@ -14,6 +14,7 @@
 package probe
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -24,12 +25,34 @@ import (
 func TestVPNIPSec(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec.jsonnet")
+	c.prepare("api/v2/cmdb/vpn.ipsec/phase1-interface", "testdata/ipsec-phase1-interface.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeVPNIPSec, c, r) {
 		t.Errorf("probeVPNIPSec() returned non-success")
 	}
 
 	em := `
+	# HELP fortigate_ipsec_dialup_clients Number of clients connected to the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_clients gauge
+	fortigate_ipsec_dialup_clients{parent="dialup",vdom="root"} 2
+	fortigate_ipsec_dialup_clients{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_dialup_receive_bytes Number of bytes received from the currently connected clients of the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_receive_bytes gauge
+	fortigate_ipsec_dialup_receive_bytes{parent="dialup",vdom="root"} 3000
+	fortigate_ipsec_dialup_receive_bytes{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_dialup_transmit_bytes Number of bytes transmitted to the currently connected clients of the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_transmit_bytes gauge
+	fortigate_ipsec_dialup_transmit_bytes{parent="dialup",vdom="root"} 12000
+	fortigate_ipsec_dialup_transmit_bytes{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_phase1_info Configuration of IPsec phase-1 tunnels, dpd_mode is the configured dead peer detection mode
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{dpd_mode="on-demand",ike_version="2",name="tunnel_1",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_up Status of IPsec phase-1 tunnel, up if at least one phase-2 is up (0 - Down, 1 - Up)
+	# TYPE fortigate_ipsec_phase1_up gauge
+	fortigate_ipsec_phase1_up{name="tunnel_1",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_uptime_seconds Seconds since the IPsec phase-1 tunnel has been created
+	# TYPE fortigate_ipsec_phase1_uptime_seconds gauge
+	fortigate_ipsec_phase1_uptime_seconds{name="tunnel_1",vdom="root"} 270801
 	# HELP fortigate_ipsec_tunnel_receive_bytes_total Total number of bytes received over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_receive_bytes_total counter
 	fortigate_ipsec_tunnel_receive_bytes_total{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1.429824e+07
@@ -50,15 +73,67 @@ func TestVPNIPSec(t *testing.T) {
 	}
 }
 
+func TestVPNIPSecWithoutConfig(t *testing.T) {
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec.jsonnet")
+	c.prepareError("api/v2/cmdb/vpn.ipsec/phase1-interface", errors.New("response code was 403, expected 200"))
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeVPNIPSec, c, r) {
+		t.Errorf("probeVPNIPSec() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_ipsec_dialup_clients Number of clients connected to the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_clients gauge
+	fortigate_ipsec_dialup_clients{parent="dialup",vdom="root"} 2
+	# HELP fortigate_ipsec_phase1_info Configuration of IPsec phase-1 tunnels, dpd_mode is the configured dead peer detection mode
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{dpd_mode="",ike_version="",name="tunnel_1",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_up Status of IPsec phase-1 tunnel, up if at least one phase-2 is up (0 - Down, 1 - Up)
+	# TYPE fortigate_ipsec_phase1_up gauge
+	fortigate_ipsec_phase1_up{name="tunnel_1",vdom="root"} 1
+	# HELP fortigate_ipsec_tunnel_up Status of IPsec tunnel (0 - Down, 1 - Up)
+	# TYPE fortigate_ipsec_tunnel_up gauge
+	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="1",parent="tunnel_1",vdom="root"} 1
+	fortigate_ipsec_tunnel_up{name="tunnel_1-sub",p2serial="12",parent="tunnel_1",vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_ipsec_dialup_clients", "fortigate_ipsec_phase1_info", "fortigate_ipsec_phase1_up", "fortigate_ipsec_tunnel_up"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
 func TestVPNIPSecWithCommonP2Names(t *testing.T) {
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/vpn/ipsec", "testdata/ipsec-common-p2.jsonnet")
+	c.prepare("api/v2/cmdb/vpn.ipsec/phase1-interface", "testdata/ipsec-phase1-interface.jsonnet")
 	r := prometheus.NewPedanticRegistry()
 	if !testProbe(probeVPNIPSec, c, r) {
 		t.Errorf("probeVPNIPSec() returned non-success")
 	}
 
 	em := `
+	# HELP fortigate_ipsec_dialup_clients Number of clients connected to the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_clients gauge
+	fortigate_ipsec_dialup_clients{parent="dialup",vdom="root"} 0
+	fortigate_ipsec_dialup_clients{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_dialup_receive_bytes Number of bytes received from the currently connected clients of the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_receive_bytes gauge
+	fortigate_ipsec_dialup_receive_bytes{parent="dialup",vdom="root"} 0
+	fortigate_ipsec_dialup_receive_bytes{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_dialup_transmit_bytes Number of bytes transmitted to the currently connected clients of the IPsec dial-up tunnel
+	# TYPE fortigate_ipsec_dialup_transmit_bytes gauge
+	fortigate_ipsec_dialup_transmit_bytes{parent="dialup",vdom="root"} 0
+	fortigate_ipsec_dialup_transmit_bytes{parent="remote-access",vdom="root"} 0
+	# HELP fortigate_ipsec_phase1_info Configuration of IPsec phase-1 tunnels, dpd_mode is the configured dead peer detection mode
+	# TYPE fortigate_ipsec_phase1_info gauge
+	fortigate_ipsec_phase1_info{dpd_mode="on-idle",ike_version="1",name="My VPN",remote_gateway="1.2.3.4",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_up Status of IPsec phase-1 tunnel, up if at least one phase-2 is up (0 - Down, 1 - Up)
+	# TYPE fortigate_ipsec_phase1_up gauge
+	fortigate_ipsec_phase1_up{name="My VPN",vdom="root"} 1
+	# HELP fortigate_ipsec_phase1_uptime_seconds Seconds since the IPsec phase-1 tunnel has been created
+	# TYPE fortigate_ipsec_phase1_uptime_seconds gauge
+	fortigate_ipsec_phase1_uptime_seconds{name="My VPN",vdom="root"} 3.978e+06
 	# HELP fortigate_ipsec_tunnel_receive_bytes_total Total number of bytes received over the IPsec tunnel
 	# TYPE fortigate_ipsec_tunnel_receive_bytes_total counter
 	fortigate_ipsec_tunnel_receive_bytes_total{name="CommonP2",p2serial="22",parent="My VPN",vdom="root"} 0
@@ -18,6 +18,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
@@ -78,19 +79,13 @@ func probeWifiClients(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric,
 		WtpName             string  `json:"wtp_name"`
 	}
 
-	type APIWifiClientResponse []struct {
-		Results []Results `json:"results"`
-		VDOM    string    `json:"vdom"`
-	}
-
-	// Consider implementing pagination to remove this limit of 1000 entries
-	var response APIWifiClientResponse
-	if err := c.Get("api/v2/monitor/wifi/client", "vdom=*&start=0&count=1000", &response); err != nil {
+	response, truncated, err := http.GetAll[Results](c, "api/v2/monitor/wifi/client", "vdom=*", config.GetConfig().MaxListEntries)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
 
-	var m []prometheus.Metric
+	m := []prometheus.Metric{probeTruncated("Wifi/Clients", truncated)}
 	for _, rs := range response {
 		for _, result := range rs.Results {
 			m = append(m, prometheus.MustNewConstMetric(clientInfo, prometheus.CounterValue, 1, rs.VDOM, result.MAC, result.Hostname, result.WtpName))
@@ -19,9 +19,15 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 )
 
 func TestProbeClients(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/wifi/client", "testdata/wifi-client.jsonnet")
 	r := prometheus.NewPedanticRegistry()
@@ -30,6 +36,9 @@ func TestProbeClients(t *testing.T) {
 	}
 
 	em := `
+        # HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+        # TYPE fortigate_probe_truncated gauge
+        fortigate_probe_truncated{probe="Wifi/Clients"} 0
         # HELP fortigate_wifi_client_bandwidth_rx_bps Bandwidth for receiving traffic
         # TYPE fortigate_wifi_client_bandwidth_rx_bps gauge
         fortigate_wifi_client_bandwidth_rx_bps{mac="00:00:00:00:00:00",vdom="root"} 0
@@ -68,3 +77,13 @@ func TestProbeClients(t *testing.T) {
 		t.Fatalf("metric compare: err %v", err)
 	}
 }
+
+func BenchmarkProbeClients(b *testing.B) {
+	if err := config.Init(); err != nil {
+		b.Fatalf("config.Init failed: %+v", err)
+	}
+
+	// 2 clients in the fixture, 5000 clients in total
+	c := newScaledClient(b, "testdata/wifi-client.jsonnet", 2500)
+	benchmarkProbe(b, probeWifiClients, c)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 	"github.com/prometheus-community/fortigate_exporter/pkg/http"
 )
 
@@ -203,18 +204,13 @@ func probeWifiManagedAP(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metri
 		WANStatus   []WANStatus `json:"wan_status"`
 	}
 
-	type managedAPResponse []struct {
-		Results []Results `json:"results"`
-	}
-
-	// Consider implementing pagination to remove this limit of 1000 entries
-	var response managedAPResponse
-	if err := c.Get("api/v2/monitor/wifi/managed_ap", "vdom=*&start=0&count=1000", &response); err != nil {
+	response, truncated, err := http.GetAll[Results](c, "api/v2/monitor/wifi/managed_ap", "vdom=*", config.GetConfig().MaxListEntries)
+	if err != nil {
 		log.Printf("Error: %v", err)
 		return nil, false
 	}
 
-	var m []prometheus.Metric
+	m := []prometheus.Metric{probeTruncated("Wifi/ManagedAP", truncated)}
 	for _, rs := range response {
 		for _, result := range rs.Results {
 			m = append(m, prometheus.MustNewConstMetric(managedAPInfo, prometheus.CounterValue, 1, result.VDOM, result.Name, result.APProfile, result.OSVersion, result.Serial))
@@ -19,9 +19,15 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
 )
 
 func TestProbeWifiManagedAP(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
 	c := newFakeClient()
 	c.prepare("api/v2/monitor/wifi/managed_ap", "testdata/wifi-managed-ap.jsonnet")
 	r := prometheus.NewPedanticRegistry()
@@ -30,6 +36,9 @@ func TestProbeWifiManagedAP(t *testing.T) {
 	}
 
 	em := `
+        # HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+        # TYPE fortigate_probe_truncated gauge
+        fortigate_probe_truncated{probe="Wifi/ManagedAP"} 0
         # HELP fortigate_wifi_managed_ap_cpu_usage_ratio CPU usage of the access point
         # TYPE fortigate_wifi_managed_ap_cpu_usage_ratio gauge
         fortigate_wifi_managed_ap_cpu_usage_ratio{ap_name="1st Floor",vdom="root"} 0.09
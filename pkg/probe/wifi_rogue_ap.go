@@ -0,0 +1,182 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type RogueAP struct {
+	BSSID   string `json:"mac"`
+	SSID    string `json:"ssid"`
+	Status  string `json:"status"`
+	WtpName string `json:"wtp_name"`
+	RadioID int    `json:"radio_id"`
+}
+
+// rogueAPStatuses are always reported, other statuses only if they are seen
+var rogueAPStatuses = []string{"rogue", "suspected", "accepted", "unclassified"}
+
+type rogueAPCount struct {
+	VDOM   string
+	Wtp    string
+	Radio  string
+	Status string
+}
+
+// rogueAPCounter counts the detected access points per detecting radio and
+// status while they are decoded, as well as the unique BSSIDs per status.
+type rogueAPCounter struct {
+	counts map[rogueAPCount]int
+	bssids map[rogueAPCount]map[string]bool
+	vdoms  map[string]bool
+	// counts and BSSIDs of the VDOM currently decoded, with an empty VDOM in the key
+	cur       map[rogueAPCount]int
+	curBSSIDs map[string]map[string]bool
+}
+
+func newRogueAPCounter() *rogueAPCounter {
+	return &rogueAPCounter{
+		counts:    make(map[rogueAPCount]int),
+		bssids:    make(map[rogueAPCount]map[string]bool),
+		vdoms:     make(map[string]bool),
+		cur:       make(map[rogueAPCount]int),
+		curBSSIDs: make(map[string]map[string]bool),
+	}
+}
+
+func (rc *rogueAPCounter) Entry(ap RogueAP) {
+	rc.cur[rogueAPCount{Wtp: ap.WtpName, Radio: strconv.Itoa(ap.RadioID), Status: ap.Status}]++
+	if rc.curBSSIDs[ap.Status] == nil {
+		rc.curBSSIDs[ap.Status] = make(map[string]bool)
+	}
+	rc.curBSSIDs[ap.Status][ap.BSSID] = true
+}
+
+func (rc *rogueAPCounter) EndVDOM(vdom string) {
+	rc.vdoms[vdom] = true
+	for k, count := range rc.cur {
+		k.VDOM = vdom
+		rc.counts[k] += count
+	}
+	for status, bssids := range rc.curBSSIDs {
+		k := rogueAPCount{VDOM: vdom, Status: status}
+		if rc.bssids[k] == nil {
+			rc.bssids[k] = make(map[string]bool)
+		}
+		for bssid := range bssids {
+			rc.bssids[k][bssid] = true
+		}
+	}
+	clear(rc.cur)
+	clear(rc.curBSSIDs)
+}
+
+func probeWifiRogueAP(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mRadio = prometheus.NewDesc(
+			"fortigate_wifi_rogue_aps",
+			"Number of foreign access points detected by the radio by status",
+			[]string{"vdom", "wtp_name", "radio_id", "status"}, nil,
+		)
+		mTotal = prometheus.NewDesc(
+			"fortigate_wifi_rogue_aps_by_status",
+			"Number of unique foreign access points (BSSIDs) detected by any radio by status",
+			[]string{"vdom", "status"}, nil,
+		)
+	)
+
+	maxEntries := config.GetConfig().MaxListEntries
+	counter := newRogueAPCounter()
+	truncated, err := http.VisitAll[RogueAP](c, "api/v2/monitor/wifi/rogue_ap", "vdom=*", maxEntries, counter)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more rogue access points than maximum (%d) allowed, counts are incomplete", maxEntries)
+	}
+
+	totals := map[rogueAPCount]int{}
+	for vdom := range counter.vdoms {
+		for _, status := range rogueAPStatuses {
+			totals[rogueAPCount{VDOM: vdom, Status: status}] = 0
+		}
+	}
+
+	for k, bssids := range counter.bssids {
+		totals[k] = len(bssids)
+	}
+
+	m := []prometheus.Metric{probeTruncated("Wifi/RogueAP", truncated)}
+	for k, count := range counter.counts {
+		m = append(m, prometheus.MustNewConstMetric(mRadio, prometheus.GaugeValue, float64(count), k.VDOM, k.Wtp, k.Radio, k.Status))
+	}
+	for k, count := range totals {
+		m = append(m, prometheus.MustNewConstMetric(mTotal, prometheus.GaugeValue, float64(count), k.VDOM, k.Status))
+	}
+
+	return m, true
+}
+
+// probeWifiRogueAPInfo reports every detected access point on its own. As
+// the number of foreign access points is outside of our control, it is only
+// enabled by the -rogue-ap-info flag.
+func probeWifiRogueAPInfo(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	mInfo := prometheus.NewDesc(
+		"fortigate_wifi_rogue_ap_info",
+		"Foreign access point detected by a radio, the value is the number of times it is reported",
+		[]string{"vdom", "bssid", "ssid", "status", "wtp_name", "radio_id"}, nil,
+	)
+
+	savedConfig := config.GetConfig()
+	if !savedConfig.RogueAPInfo {
+		return nil, true
+	}
+
+	maxEntries := savedConfig.MaxListEntries
+	res, truncated, err := http.GetAll[RogueAP](c, "api/v2/monitor/wifi/rogue_ap", "vdom=*", maxEntries)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more rogue access points than maximum (%d) allowed, ignoring the remaining", maxEntries)
+	}
+
+	// A radio may report the same access point several times, e.g. on different channels
+	type rogueAPInfo struct {
+		VDOM string
+		RogueAP
+	}
+	aps := map[rogueAPInfo]int{}
+	for _, r := range res {
+		for _, ap := range r.Results {
+			aps[rogueAPInfo{r.VDOM, ap}]++
+		}
+	}
+
+	m := []prometheus.Metric{probeTruncated("Wifi/RogueAP/Info", truncated)}
+	for ap, count := range aps {
+		m = append(m, prometheus.MustNewConstMetric(mInfo, prometheus.GaugeValue, float64(count), ap.VDOM, ap.BSSID, ap.SSID, ap.Status, ap.WtpName, strconv.Itoa(ap.RadioID)))
+	}
+
+	return m, true
+}
@@ -0,0 +1,107 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestWifiRogueAP(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/wifi/rogue_ap", "testdata/wifi-rogue-ap.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeWifiRogueAP, c, r) {
+		t.Errorf("probeWifiRogueAP() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Wifi/RogueAP"} 0
+	# HELP fortigate_wifi_rogue_aps Number of foreign access points detected by the radio by status
+	# TYPE fortigate_wifi_rogue_aps gauge
+	fortigate_wifi_rogue_aps{radio_id="1",status="accepted",vdom="root",wtp_name="2nd Floor"} 1
+	fortigate_wifi_rogue_aps{radio_id="1",status="rogue",vdom="root",wtp_name="3rd Floor"} 1
+	fortigate_wifi_rogue_aps{radio_id="1",status="suspected",vdom="root",wtp_name="3rd Floor"} 1
+	fortigate_wifi_rogue_aps{radio_id="1",status="unclassified",vdom="root",wtp_name="3rd Floor"} 2
+	fortigate_wifi_rogue_aps{radio_id="2",status="accepted",vdom="root",wtp_name="2nd Floor"} 1
+	fortigate_wifi_rogue_aps{radio_id="2",status="rogue",vdom="root",wtp_name="2nd Floor"} 2
+	# HELP fortigate_wifi_rogue_aps_by_status Number of unique foreign access points (BSSIDs) detected by any radio by status
+	# TYPE fortigate_wifi_rogue_aps_by_status gauge
+	fortigate_wifi_rogue_aps_by_status{status="accepted",vdom="root"} 2
+	fortigate_wifi_rogue_aps_by_status{status="rogue",vdom="root"} 1
+	fortigate_wifi_rogue_aps_by_status{status="suspected",vdom="root"} 1
+	fortigate_wifi_rogue_aps_by_status{status="unclassified",vdom="root"} 2
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
+
+func TestWifiRogueAPInfoDisabled(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
+	m, ok := probeWifiRogueAPInfo(newFakeClient(), &TargetMetadata{VersionMajor: 7, VersionMinor: 4})
+	if !ok || len(m) != 0 {
+		t.Errorf("probeWifiRogueAPInfo() returned %d metrics, %v, expected none without -rogue-ap-info", len(m), ok)
+	}
+}
+
+func TestWifiRogueAPInfo(t *testing.T) {
+	if err := flag.Set("rogue-ap-info", "true"); err != nil {
+		t.Fatalf("flag.Set failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = flag.Set("rogue-ap-info", "false")
+		config.MustReInit()
+	})
+	config.MustReInit()
+
+	c := newFakeClient()
+	c.prepare("api/v2/monitor/wifi/rogue_ap", "testdata/wifi-rogue-ap.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeWifiRogueAPInfo, c, r) {
+		t.Errorf("probeWifiRogueAPInfo() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_wifi_rogue_ap_info Foreign access point detected by a radio, the value is the number of times it is reported
+	# TYPE fortigate_wifi_rogue_ap_info gauge
+	fortigate_wifi_rogue_ap_info{bssid="3c:84:6a:00:00:20",radio_id="1",ssid="DIRECT-printer",status="unclassified",vdom="root",wtp_name="3rd Floor"} 1
+	fortigate_wifi_rogue_ap_info{bssid="3c:84:6a:00:00:21",radio_id="1",ssid="neighbour",status="unclassified",vdom="root",wtp_name="3rd Floor"} 1
+	fortigate_wifi_rogue_ap_info{bssid="90:6c:ac:00:00:01",radio_id="1",ssid="corp-wifi",status="accepted",vdom="root",wtp_name="2nd Floor"} 1
+	fortigate_wifi_rogue_ap_info{bssid="90:6c:ac:00:00:02",radio_id="2",ssid="corp-wifi",status="accepted",vdom="root",wtp_name="2nd Floor"} 1
+	fortigate_wifi_rogue_ap_info{bssid="a4:2b:b0:00:00:10",radio_id="1",ssid="FreeWifi",status="rogue",vdom="root",wtp_name="3rd Floor"} 1
+	fortigate_wifi_rogue_ap_info{bssid="a4:2b:b0:00:00:10",radio_id="2",ssid="FreeWifi",status="rogue",vdom="root",wtp_name="2nd Floor"} 2
+	fortigate_wifi_rogue_ap_info{bssid="f0:9f:c2:00:00:30",radio_id="1",ssid="",status="suspected",vdom="root",wtp_name="3rd Floor"} 1
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em), "fortigate_wifi_rogue_ap_info"); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}
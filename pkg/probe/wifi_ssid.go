@@ -0,0 +1,198 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+	"github.com/prometheus-community/fortigate_exporter/pkg/http"
+)
+
+type SSIDClient struct {
+	VAP            string  `json:"vap_name"`
+	SSID           string  `json:"ssid"`
+	Authentication string  `json:"authentication"`
+	BandwidthTx    float64 `json:"bandwidth_tx"`
+	BandwidthRx    float64 `json:"bandwidth_rx"`
+	Signal         float64 `json:"signal"`
+}
+
+type VAP struct {
+	Name string `json:"name"`
+	SSID string `json:"ssid"`
+}
+
+type VAPResponse struct {
+	Results []VAP  `json:"results"`
+	VDOM    string `json:"vdom"`
+}
+
+type ssidKey struct {
+	VDOM string
+	SSID string
+	VAP  string
+}
+
+type ssidStats struct {
+	clients     int
+	authFailed  int
+	bandwidthTx float64
+	bandwidthRx float64
+	signals     map[float64]uint64
+	signalSum   float64
+}
+
+// ssidSignalBuckets are the upper bounds in dBm of the client signal
+// strength histogram, -67 dBm is commonly required for voice and video.
+var ssidSignalBuckets = []float64{-90, -80, -70, -67, -60, -50}
+
+func newSSIDStats() *ssidStats {
+	s := &ssidStats{signals: make(map[float64]uint64)}
+	for _, b := range ssidSignalBuckets {
+		s.signals[b] = 0
+	}
+	return s
+}
+
+func (s *ssidStats) add(o *ssidStats) {
+	s.clients += o.clients
+	s.authFailed += o.authFailed
+	s.bandwidthTx += o.bandwidthTx
+	s.bandwidthRx += o.bandwidthRx
+	for b, n := range o.signals {
+		s.signals[b] += n
+	}
+	s.signalSum += o.signalSum
+}
+
+// ssidCounter aggregates the wifi clients per SSID while they are decoded.
+type ssidCounter struct {
+	stats map[ssidKey]*ssidStats
+	// stats of the VDOM currently decoded, with an empty VDOM in the key
+	cur map[ssidKey]*ssidStats
+}
+
+func newSSIDCounter() *ssidCounter {
+	return &ssidCounter{
+		stats: make(map[ssidKey]*ssidStats),
+		cur:   make(map[ssidKey]*ssidStats),
+	}
+}
+
+func (sc *ssidCounter) Entry(c SSIDClient) {
+	k := ssidKey{SSID: c.SSID, VAP: c.VAP}
+	s, ok := sc.cur[k]
+	if !ok {
+		s = newSSIDStats()
+		sc.cur[k] = s
+	}
+	s.clients++
+	if c.Authentication == "fail" {
+		s.authFailed++
+	}
+	s.bandwidthTx += c.BandwidthTx
+	s.bandwidthRx += c.BandwidthRx
+	for _, b := range ssidSignalBuckets {
+		if c.Signal <= b {
+			s.signals[b]++
+		}
+	}
+	s.signalSum += c.Signal
+}
+
+// EndVDOM is called once per page, so a VDOM may end several times
+func (sc *ssidCounter) EndVDOM(vdom string) {
+	for k, s := range sc.cur {
+		k.VDOM = vdom
+		if prev, ok := sc.stats[k]; ok {
+			prev.add(s)
+		} else {
+			sc.stats[k] = s
+		}
+	}
+	clear(sc.cur)
+}
+
+func probeWifiSSID(c http.FortiHTTP, _ *TargetMetadata) ([]prometheus.Metric, bool) {
+	var (
+		mClients = prometheus.NewDesc(
+			"fortigate_wifi_ssid_clients",
+			"Number of clients connected to the SSID",
+			[]string{"vdom", "ssid", "vap"}, nil,
+		)
+		mAuthFailed = prometheus.NewDesc(
+			"fortigate_wifi_ssid_clients_auth_failed",
+			"Number of clients of the SSID which failed authentication",
+			[]string{"vdom", "ssid", "vap"}, nil,
+		)
+		mBandwidthRx = prometheus.NewDesc(
+			"fortigate_wifi_ssid_bandwidth_rx_bps",
+			"Bandwidth for receiving traffic of all clients of the SSID",
+			[]string{"vdom", "ssid", "vap"}, nil,
+		)
+		mBandwidthTx = prometheus.NewDesc(
+			"fortigate_wifi_ssid_bandwidth_tx_bps",
+			"Bandwidth for transmitting traffic of all clients of the SSID",
+			[]string{"vdom", "ssid", "vap"}, nil,
+		)
+		mSignal = prometheus.NewDesc(
+			"fortigate_wifi_ssid_client_signal_strength_dBm",
+			"Distribution of the signal strength of the clients of the SSID",
+			[]string{"vdom", "ssid", "vap"}, nil,
+		)
+	)
+
+	// The configured VAPs are only used to report SSIDs without clients, all
+	// live numbers are aggregated from the wifi clients
+	var vaps []VAPResponse
+	if err := c.Get("api/v2/cmdb/wireless-controller/vap", "vdom=*&format=name|ssid", &vaps); err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+
+	maxEntries := config.GetConfig().MaxListEntries
+	counter := newSSIDCounter()
+	truncated, err := http.VisitAll[SSIDClient](c, "api/v2/monitor/wifi/client", "vdom=*", maxEntries, counter)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return nil, false
+	}
+	if truncated {
+		log.Printf("Warning: Received more wifi clients than maximum (%d) allowed, SSID statistics are incomplete", maxEntries)
+	}
+
+	// Report configured SSIDs without clients as well
+	for _, r := range vaps {
+		for _, v := range r.Results {
+			k := ssidKey{VDOM: r.VDOM, SSID: v.SSID, VAP: v.Name}
+			if _, ok := counter.stats[k]; !ok {
+				counter.stats[k] = newSSIDStats()
+			}
+		}
+	}
+
+	m := []prometheus.Metric{probeTruncated("Wifi/SSID", truncated)}
+	for k, s := range counter.stats {
+		m = append(m, prometheus.MustNewConstMetric(mClients, prometheus.GaugeValue, float64(s.clients), k.VDOM, k.SSID, k.VAP))
+		m = append(m, prometheus.MustNewConstMetric(mAuthFailed, prometheus.GaugeValue, float64(s.authFailed), k.VDOM, k.SSID, k.VAP))
+		m = append(m, prometheus.MustNewConstMetric(mBandwidthRx, prometheus.GaugeValue, s.bandwidthRx, k.VDOM, k.SSID, k.VAP))
+		m = append(m, prometheus.MustNewConstMetric(mBandwidthTx, prometheus.GaugeValue, s.bandwidthTx, k.VDOM, k.SSID, k.VAP))
+		m = append(m, prometheus.MustNewConstHistogram(mSignal, uint64(s.clients), s.signalSum, s.signals, k.VDOM, k.SSID, k.VAP))
+	}
+
+	return m, true
+}
@@ -0,0 +1,97 @@
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/prometheus-community/fortigate_exporter/internal/config"
+)
+
+func TestWifiSSID(t *testing.T) {
+	if err := config.Init(); err != nil {
+		t.Fatalf("config.Init failed: %+v", err)
+	}
+
+	c := newFakeClient()
+	c.prepare("api/v2/cmdb/wireless-controller/vap", "testdata/wireless-controller-vap.jsonnet")
+	c.prepare("api/v2/monitor/wifi/client", "testdata/wifi-client-ssid.jsonnet")
+	r := prometheus.NewPedanticRegistry()
+	if !testProbe(probeWifiSSID, c, r) {
+		t.Errorf("probeWifiSSID() returned non-success")
+	}
+
+	em := `
+	# HELP fortigate_probe_truncated Whether the probe received more list entries than allowed and only reports a part of them
+	# TYPE fortigate_probe_truncated gauge
+	fortigate_probe_truncated{probe="Wifi/SSID"} 0
+	# HELP fortigate_wifi_ssid_bandwidth_rx_bps Bandwidth for receiving traffic of all clients of the SSID
+	# TYPE fortigate_wifi_ssid_bandwidth_rx_bps gauge
+	fortigate_wifi_ssid_bandwidth_rx_bps{ssid="corp-wifi",vap="corp",vdom="root"} 16200
+	fortigate_wifi_ssid_bandwidth_rx_bps{ssid="guest-wifi",vap="guest",vdom="root"} 800
+	fortigate_wifi_ssid_bandwidth_rx_bps{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	# HELP fortigate_wifi_ssid_bandwidth_tx_bps Bandwidth for transmitting traffic of all clients of the SSID
+	# TYPE fortigate_wifi_ssid_bandwidth_tx_bps gauge
+	fortigate_wifi_ssid_bandwidth_tx_bps{ssid="corp-wifi",vap="corp",vdom="root"} 2500
+	fortigate_wifi_ssid_bandwidth_tx_bps{ssid="guest-wifi",vap="guest",vdom="root"} 100
+	fortigate_wifi_ssid_bandwidth_tx_bps{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	# HELP fortigate_wifi_ssid_client_signal_strength_dBm Distribution of the signal strength of the clients of the SSID
+	# TYPE fortigate_wifi_ssid_client_signal_strength_dBm histogram
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-90"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-80"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-70"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-67"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-60"} 2
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="-50"} 2
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="corp-wifi",vap="corp",vdom="root",le="+Inf"} 3
+	fortigate_wifi_ssid_client_signal_strength_dBm_sum{ssid="corp-wifi",vap="corp",vdom="root"} -196
+	fortigate_wifi_ssid_client_signal_strength_dBm_count{ssid="corp-wifi",vap="corp",vdom="root"} 3
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-90"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-80"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-70"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-67"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-60"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="-50"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="guest-wifi",vap="guest",vdom="root",le="+Inf"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_sum{ssid="guest-wifi",vap="guest",vdom="root"} -71
+	fortigate_wifi_ssid_client_signal_strength_dBm_count{ssid="guest-wifi",vap="guest",vdom="root"} 1
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-90"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-80"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-70"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-67"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-60"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="-50"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_bucket{ssid="iot-wifi",vap="iot",vdom="root",le="+Inf"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_sum{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	fortigate_wifi_ssid_client_signal_strength_dBm_count{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	# HELP fortigate_wifi_ssid_clients Number of clients connected to the SSID
+	# TYPE fortigate_wifi_ssid_clients gauge
+	fortigate_wifi_ssid_clients{ssid="corp-wifi",vap="corp",vdom="root"} 3
+	fortigate_wifi_ssid_clients{ssid="guest-wifi",vap="guest",vdom="root"} 1
+	fortigate_wifi_ssid_clients{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	# HELP fortigate_wifi_ssid_clients_auth_failed Number of clients of the SSID which failed authentication
+	# TYPE fortigate_wifi_ssid_clients_auth_failed gauge
+	fortigate_wifi_ssid_clients_auth_failed{ssid="corp-wifi",vap="corp",vdom="root"} 1
+	fortigate_wifi_ssid_clients_auth_failed{ssid="guest-wifi",vap="guest",vdom="root"} 0
+	fortigate_wifi_ssid_clients_auth_failed{ssid="iot-wifi",vap="iot",vdom="root"} 0
+	`
+
+	if err := testutil.GatherAndCompare(r, strings.NewReader(em)); err != nil {
+		t.Fatalf("metric compare: err %v", err)
+	}
+}